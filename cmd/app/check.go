@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/check"
+	"github.com/jjmrocha/knowledge-mcp/internal/git"
+)
+
+// errCheckFailed is returned when the check found errors, once they have
+// been reported.
+var errCheckFailed = errors.New("the knowledge store has errors")
+
+// runCheck verifies the store and prints the problems found to out. With
+// -fix it also repairs the problems that can be repaired safely. It fails
+// only when errors are left, so that it can guard a pre-commit hook.
+func runCheck(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet(serverName+" check", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s check [flags] <root-dir>\n", serverName)
+		flags.PrintDefaults()
+	}
+
+	asJSON := flags.Bool("json", false, "print the report as JSON")
+	fix := flags.Bool("fix", false, "repair the problems that can be repaired safely")
+	useGit := flags.Bool("git", false, "with -fix, commit every repaired file to a git repository in the root directory")
+	actor := flags.String("actor", defaultActor(), "name recorded as the author of git commits")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("exactly one knowledge store root directory is required")
+	}
+
+	var report *check.Report
+
+	if *fix {
+		var repo *git.Repo
+
+		if *useGit {
+			if repo, err = git.Open(flags.Arg(0), *actor); err != nil {
+				return fmt.Errorf("failed to open git repository of %q: %w", flags.Arg(0), err)
+			}
+		}
+
+		report, err = check.Fix(flags.Arg(0), time.Now().UTC().Truncate(time.Second), repo)
+	} else {
+		report, err = check.Run(flags.Arg(0))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+	} else {
+		err = printReport(out, report)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to print the report: %w", err)
+	}
+
+	if report.Failed() {
+		return errCheckFailed
+	}
+
+	return nil
+}
+
+func printReport(out io.Writer, report *check.Report) error {
+	for _, p := range report.Problems {
+		severity := p.Severity
+		if p.Fixed {
+			severity = "fixed"
+		}
+
+		if _, err := fmt.Fprintf(out, "%s: %s: %s: %s\n", severity, p.Path, p.Code, p.Message); err != nil {
+			return err
+		}
+
+		if p.Suggestion != "" {
+			if _, err := fmt.Fprintf(out, "  suggestion: %s\n", p.Suggestion); err != nil {
+				return err
+			}
+		}
+	}
+
+	summary := fmt.Sprintf("checked %d files: %d errors, %d warnings", report.Files, report.Errors, report.Warnings)
+	if report.Fixed > 0 {
+		summary += fmt.Sprintf(", %d fixed in %d files", report.Fixed, len(report.Repaired))
+	}
+
+	_, err := fmt.Fprintln(out, summary)
+	return err
+}
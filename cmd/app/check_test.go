@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/check"
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+const (
+	pricingTag  = "scio://tags/pricing"
+	discountTag = "scio://tags/discount"
+)
+
+// newStore returns the root of a store holding the pricing tag and the
+// discount tag beneath it.
+func newStore(t *testing.T, discountBroader ...string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	require.NoError(t, storage.InitRootDirs(root))
+	saveTag(t, root, &model.Tag{URI: pricingTag, Narrower: []string{discountTag}})
+	saveTag(t, root, &model.Tag{URI: discountTag, Broader: discountBroader})
+	return root
+}
+
+func saveTag(t *testing.T, root string, tag *model.Tag) {
+	t.Helper()
+
+	tag.Entity, tag.Schema, tag.Version = model.EntityTypeTag, model.SchemaVersion, 1
+	content, err := model.EncodeTag(tag)
+	require.NoError(t, err)
+
+	u, err := uri.Parse(tag.URI)
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveFile(root, u, []byte(content)))
+}
+
+func TestRunCheck_JSON(t *testing.T) {
+	// given
+	root := newStore(t, pricingTag)
+	var out bytes.Buffer
+	// when
+	err := runCheck([]string{"-json", root}, &out)
+	// then
+	require.NoError(t, err)
+
+	var report check.Report
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, root, report.Root)
+	assert.Equal(t, 2, report.Files)
+	assert.Empty(t, report.Problems)
+}
+
+func TestRunCheck_Text(t *testing.T) {
+	// given
+	root := newStore(t, pricingTag)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "tags", "notes.txt"), nil, 0o600))
+	var out bytes.Buffer
+	// when
+	err := runCheck([]string{root}, &out)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "warning: tags/notes.txt: stray_file: not an entity file of the store layout\n"+
+		"  suggestion: Move the file out of the knowledge store\n"+
+		"checked 3 files: 0 errors, 1 warnings\n", out.String())
+}
+
+func TestRunCheck_Errors(t *testing.T) {
+	// given
+	root := newStore(t)
+	var out bytes.Buffer
+	// when
+	err := runCheck([]string{root}, &out)
+	// then
+	require.ErrorIs(t, err, errCheckFailed)
+	assert.Contains(t, out.String(), "error: tags/pricing.md: hierarchy_asymmetry: ")
+	assert.Contains(t, out.String(), "checked 2 files: 1 errors, 0 warnings\n")
+}
+
+func TestRunCheck_Fix(t *testing.T) {
+	// given
+	root := newStore(t)
+	var out bytes.Buffer
+	// when
+	err := runCheck([]string{"-fix", root}, &out)
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "fixed: tags/pricing.md: hierarchy_asymmetry: ")
+	assert.Contains(t, out.String(), "checked 2 files: 0 errors, 0 warnings, 1 fixed in 1 files\n")
+
+	out.Reset()
+	require.NoError(t, runCheck([]string{root}, &out))
+	assert.Equal(t, "checked 2 files: 0 errors, 0 warnings\n", out.String())
+}
@@ -1,4 +1,148 @@
 package main
 
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/git"
+	"github.com/jjmrocha/knowledge-mcp/internal/index"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+	"github.com/jjmrocha/knowledge-mcp/internal/tools"
+)
+
+const (
+	serverName    = "knowledge-mcp"
+	serverVersion = "0.1.0"
+	// tempFileGrace is how old a temporary file must be before it is taken
+	// for the leftover of an interrupted write.
+	tempFileGrace = time.Minute
+)
+
 func main() {
+	log.SetFlags(0)
+	log.SetPrefix(serverName + ": ")
+
+	if err := run(os.Args[1:]); err != nil {
+		if errors.Is(err, errCheckFailed) {
+			os.Exit(1)
+		}
+
+		log.Fatal(err)
+	}
+}
+
+func run(args []string) error {
+	if len(args) > 0 && args[0] == "check" {
+		return runCheck(args[1:], os.Stdout)
+	}
+
+	flags := flag.NewFlagSet(serverName, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s [flags] <root-dir>\n       %s check [flags] <root-dir>\n", serverName, serverName)
+		flags.PrintDefaults()
+	}
+
+	useGit := flags.Bool("git", false, "commit every change to a git repository in the root directory")
+	actor := flags.String("actor", defaultActor(), "name recorded as the author of git commits")
+	watch := flags.Duration("watch", 2*time.Second, "how often to check for files changed outside the server, 0 to never check")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("exactly one knowledge store root directory is required")
+	}
+
+	rootDir := flags.Arg(0)
+
+	if err := storage.InitRootDirs(rootDir); err != nil {
+		return fmt.Errorf("failed to initialize root directory %q: %w", rootDir, err)
+	}
+
+	if err := cleanTempFiles(rootDir); err != nil {
+		return fmt.Errorf("failed to clean root directory %q: %w", rootDir, err)
+	}
+
+	idx, err := index.Open(rootDir)
+	if err != nil {
+		return fmt.Errorf("failed to open index of %q: %w", rootDir, err)
+	}
+
+	defer func() {
+		if err := idx.Close(); err != nil {
+			log.Printf("failed to close index: %v", err)
+		}
+	}()
+
+	options := []service.Option{service.WithIndex(idx)}
+
+	if *useGit {
+		repo, err := git.Open(rootDir, *actor)
+		if err != nil {
+			return fmt.Errorf("failed to open git repository of %q: %w", rootDir, err)
+		}
+
+		options = append(options, service.WithGit(repo))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: serverName, Version: serverVersion}, tools.ServerOptions())
+	svc := service.New(rootDir, options...)
+	tools.Register(server, svc)
+
+	if *watch > 0 {
+		go tools.Watch(ctx, server, svc, *watch)
+	}
+
+	if err := server.Run(ctx, &mcp.StdioTransport{}); err != nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("server stopped: %w", err)
+	}
+
+	return nil
+}
+
+// cleanTempFiles removes the temporary files left by interrupted writes.
+// The store lock keeps it from racing the writes of other servers sharing
+// the root directory, and recent files are kept in case one slips through.
+func cleanTempFiles(rootDir string) error {
+	lock, err := storage.LockStore(rootDir)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := lock.Unlock(); err != nil {
+			log.Printf("failed to unlock the store: %v", err)
+		}
+	}()
+
+	removed, err := storage.CleanTempFiles(rootDir, tempFileGrace)
+	for _, name := range removed {
+		log.Printf("removed %s left by an interrupted write", name)
+	}
+
+	return err
+}
+
+// defaultActor names the current user, falling back to the server name.
+func defaultActor() string {
+	if user := os.Getenv("USER"); user != "" {
+		return user
+	}
+
+	return serverName
 }
@@ -0,0 +1,710 @@
+// Package check verifies the integrity of a knowledge store directly on
+// disk, reporting the files and references the server would reject or
+// silently work around, and repairs the ones that can be repaired safely.
+package check
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sahilm/fuzzy"
+	"gopkg.in/yaml.v3"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/entity"
+	"github.com/jjmrocha/knowledge-mcp/internal/git"
+	"github.com/jjmrocha/knowledge-mcp/internal/index"
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// Severities of a problem. Only errors fail a check.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Problem codes.
+const (
+	CodeParseFailed        = "parse_failed"
+	CodeURIMismatch        = "uri_mismatch"
+	CodeEntityMismatch     = "entity_mismatch"
+	CodeDanglingTarget     = "dangling_target"
+	CodeUnknownRelation    = "unknown_relation_type"
+	CodeUnknownTag         = "unknown_tag"
+	CodeHierarchyAsymmetry = "hierarchy_asymmetry"
+	CodeInverseMismatch    = "inverse_mismatch"
+	CodeDuplicate          = "duplicate"
+	CodeMissingList        = "missing_list"
+	CodeStrayFile          = "stray_file"
+	CodeFixFailed          = "fix_failed"
+)
+
+// Problem is one finding of a check. Path is relative to the root directory
+// and URI is the URI its location stands for, when it stands for one.
+// Fixed is set on the problems repaired by Fix, and Suggestion tells how to
+// solve a problem that cannot be repaired automatically.
+type Problem struct {
+	Severity   string `json:"severity"`
+	Code       string `json:"code"`
+	Path       string `json:"path"`
+	URI        string `json:"uri,omitempty"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
+	Fixed      bool   `json:"fixed,omitempty"`
+}
+
+// Report lists the problems found in the store at Root, sorted by path.
+// Errors and Warnings count the problems left; Fixed counts the problems
+// repaired by rewriting the files in Repaired.
+type Report struct {
+	Root     string    `json:"root"`
+	Files    int       `json:"files"`
+	Errors   int       `json:"errors"`
+	Warnings int       `json:"warnings"`
+	Fixed    int       `json:"fixed"`
+	Repaired []string  `json:"repaired"`
+	Problems []Problem `json:"problems"`
+}
+
+// Failed reports whether errors were found that are left unrepaired.
+func (r *Report) Failed() bool {
+	return r.Errors > 0
+}
+
+// file is an entity file that could be parsed. Changed is set once a repair
+// modified its entity.
+type file struct {
+	path    string
+	uri     *uri.URI
+	entity  any
+	changed bool
+}
+
+type checker struct {
+	rootDir string
+	// fix applies the repair of every problem that has one.
+	fix    bool
+	report *Report
+	// repairs holds the index in report.Problems of the problems fixed by
+	// modifying each file.
+	repairs   map[*file][]int
+	existing  map[string]bool
+	files     []*file
+	tags      map[string]*file
+	relTypes  map[string]*file
+	redirects map[string]string
+}
+
+func newChecker(rootDir string, fix bool) *checker {
+	return &checker{
+		rootDir:   rootDir,
+		fix:       fix,
+		report:    &Report{Root: rootDir, Repaired: []string{}, Problems: []Problem{}},
+		repairs:   make(map[*file][]int),
+		existing:  make(map[string]bool),
+		tags:      make(map[string]*file),
+		relTypes:  make(map[string]*file),
+		redirects: make(map[string]string),
+	}
+}
+
+// Run checks every file under rootDir without changing any.
+func Run(rootDir string) (*Report, error) {
+	c := newChecker(rootDir, false)
+
+	if err := c.run(); err != nil {
+		return nil, err
+	}
+
+	c.finish()
+	return c.report, nil
+}
+
+// Fix checks every file under rootDir and repairs the problems that can be
+// repaired without guessing: uri fields are rewritten from the file path,
+// missing broader/narrower back-links and inverse-of back-pointers are
+// added, exact duplicates are dropped and missing lists are written empty.
+// Every repaired file gets its version incremented and now as its last
+// update, and is committed to repo when it is not nil. A file that cannot
+// be repaired is reported with a fix_failed error, its problems are left
+// unfixed, and the other files are still repaired. The store stays locked
+// while it is being repaired.
+func Fix(rootDir string, now time.Time, repo *git.Repo) (*Report, error) {
+	lock, err := storage.LockStore(rootDir)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = lock.Unlock() }()
+
+	c := newChecker(rootDir, true)
+
+	if err := c.run(); err != nil {
+		return nil, err
+	}
+
+	c.save(now, repo)
+	c.finish()
+	return c.report, nil
+}
+
+func (c *checker) run() error {
+	if err := c.loadRedirects(); err != nil {
+		return err
+	}
+
+	var paths []string
+
+	root := filepath.Clean(c.rootDir)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// the git repository and the entity history are never walked
+		if d.IsDir() && filepath.Dir(path) == root && (d.Name() == ".git" || d.Name() == storage.HistoryDir) {
+			return filepath.SkipDir
+		}
+
+		if !d.IsDir() {
+			paths = append(paths, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to walk %s: %w", c.rootDir, err)
+	}
+
+	for _, path := range paths {
+		if err := c.checkFile(path); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range c.files {
+		c.checkReferences(f)
+	}
+
+	return nil
+}
+
+// finish sorts the problems by path and counts them.
+func (c *checker) finish() {
+	sort.SliceStable(c.report.Problems, func(i, j int) bool {
+		return c.report.Problems[i].Path < c.report.Problems[j].Path
+	})
+
+	for _, p := range c.report.Problems {
+		switch {
+		case p.Fixed:
+			c.report.Fixed++
+		case p.Severity == SeverityError:
+			c.report.Errors++
+		default:
+			c.report.Warnings++
+		}
+	}
+}
+
+func (c *checker) loadRedirects() error {
+	content, err := storage.ReadRedirects(c.rootDir)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", storage.RedirectsFile, err)
+	}
+
+	if content == nil {
+		return nil
+	}
+
+	redirects, err := model.ParseRedirects(string(content))
+	if err != nil {
+		// an unreadable redirects file only costs suggestions
+		return nil
+	}
+
+	for _, r := range redirects {
+		c.redirects[r.From] = r.To
+	}
+
+	return nil
+}
+
+func newProblem(severity, code string, f *file, format string, args ...any) Problem {
+	p := Problem{Severity: severity, Code: code, Path: f.path, Message: fmt.Sprintf(format, args...)}
+	if f.uri != nil {
+		p.URI = f.uri.Raw
+	}
+
+	return p
+}
+
+// found records a problem of f that cannot be repaired automatically.
+func (c *checker) found(severity, code string, f *file, suggestion, format string, args ...any) {
+	p := newProblem(severity, code, f, format, args...)
+	p.Suggestion = suggestion
+	c.report.Problems = append(c.report.Problems, p)
+}
+
+// fixable records a problem of f that repair solves by modifying the entity
+// of target. The repair is only applied when fixing.
+func (c *checker) fixable(severity, code string, f, target *file, repair func(), format string, args ...any) {
+	p := newProblem(severity, code, f, format, args...)
+
+	if c.fix {
+		repair()
+		target.changed = true
+		p.Fixed = true
+		c.repairs[target] = append(c.repairs[target], len(c.report.Problems))
+	}
+
+	c.report.Problems = append(c.report.Problems, p)
+}
+
+// ignored reports whether the file at rel, relative to the root directory,
+// is kept there by the server or by git rather than being an entity file.
+func ignored(rel string) bool {
+	first, _, nested := strings.Cut(rel, "/")
+
+	switch {
+	case first == ".git" || first == storage.HistoryDir:
+		return true
+	case nested:
+		return false
+	default:
+		return rel == storage.RedirectsFile || rel == storage.LockFile || rel == ".gitignore" ||
+			strings.HasPrefix(rel, index.FileName)
+	}
+}
+
+func (c *checker) checkFile(path string) error {
+	rel, err := filepath.Rel(c.rootDir, path)
+	if err != nil {
+		return err
+	}
+
+	rel = filepath.ToSlash(rel)
+	if ignored(rel) {
+		return nil
+	}
+
+	c.report.Files++
+	f := &file{path: rel}
+
+	u, err := storage.URIFromFileName(c.rootDir, path)
+	if err != nil {
+		c.found(SeverityWarning, CodeStrayFile, f, "Move the file out of the knowledge store", "not an entity file of the store layout")
+		return nil
+	}
+
+	f.uri = u
+	c.existing[u.Raw] = true
+
+	content, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", rel, err)
+	}
+
+	parsed, err := entity.ParseContent(string(content))
+	if err != nil {
+		c.found(SeverityError, CodeParseFailed, f, "", "%v", err)
+		return nil
+	}
+
+	var header struct {
+		Entity string `yaml:"entity"`
+		URI    string `yaml:"uri"`
+	}
+
+	if err := yaml.Unmarshal([]byte(parsed.Metadata), &header); err != nil {
+		c.found(SeverityError, CodeParseFailed, f, "", "invalid frontmatter: %v", err)
+		return nil
+	}
+
+	if header.Entity != u.Entity {
+		c.found(SeverityError, CodeEntityMismatch, f, "Move the file to the folder of its entity type",
+			"entity is %q but the file is stored as a %s", header.Entity, u.Entity)
+		return nil
+	}
+
+	e, err := parseEntity(u.Entity, string(content))
+	if err != nil {
+		c.found(SeverityError, CodeParseFailed, f, "", "%v", err)
+		return nil
+	}
+
+	f.entity = e
+	c.files = append(c.files, f)
+
+	if header.URI != u.Raw {
+		c.fixable(SeverityError, CodeURIMismatch, f, f, func() { setURI(e, u.Raw) },
+			"uri is %q but the file is stored at %s", header.URI, u.Raw)
+	}
+
+	c.checkLists(f)
+
+	switch u.Entity {
+	case model.EntityTypeTag:
+		c.tags[u.Raw] = f
+	case model.EntityTypeRelation:
+		c.relTypes[u.Raw] = f
+	}
+
+	return nil
+}
+
+func (c *checker) checkReferences(f *file) {
+	switch e := f.entity.(type) {
+	case *model.Tag:
+		c.checkTag(f, e)
+	case *model.RelationType:
+		c.checkRelationType(f, e)
+	case *model.Context:
+		c.checkTagged(f, e.Tags, e.Relations)
+	case *model.Domain:
+		c.checkTagged(f, e.Tags, e.Relations)
+	case *model.Concept:
+		c.checkTagged(f, e.Tags, e.Relations)
+	}
+}
+
+func (c *checker) checkTagged(f *file, tags []string, relations []model.RelationRef) {
+	for _, tag := range tags {
+		if !c.exists(tag, model.EntityTypeTag) {
+			c.found(SeverityError, CodeUnknownTag, f, c.suggest(tag, model.EntityTypeTag), "tag %s does not exist", tag)
+		}
+	}
+
+	for _, ref := range relations {
+		if !c.exists(ref.Type, model.EntityTypeRelation) {
+			c.found(SeverityError, CodeUnknownRelation, f, c.suggest(ref.Type, model.EntityTypeRelation),
+				"relation type %s does not exist", ref.Type)
+		}
+
+		if !c.existing[ref.Target] {
+			c.found(SeverityError, CodeDanglingTarget, f, c.suggest(ref.Target, ""),
+				"%s target %s does not exist", ref.Type, ref.Target)
+		}
+	}
+}
+
+func (c *checker) checkTag(f *file, t *model.Tag) {
+	for _, broader := range t.Broader {
+		c.checkHierarchyLink(f, broader, "broader", "narrower", func(other *model.Tag) *[]string { return &other.Narrower })
+	}
+
+	for _, narrower := range t.Narrower {
+		c.checkHierarchyLink(f, narrower, "narrower", "broader", func(other *model.Tag) *[]string { return &other.Broader })
+	}
+}
+
+// checkHierarchyLink verifies that the tag linked from f as field links
+// back to f as backField.
+func (c *checker) checkHierarchyLink(f *file, linked, field, backField string, back func(*model.Tag) *[]string) {
+	other, ok := c.tags[linked]
+	if !ok {
+		if !c.exists(linked, model.EntityTypeTag) {
+			c.found(SeverityError, CodeUnknownTag, f, c.suggest(linked, model.EntityTypeTag),
+				"%s tag %s does not exist", field, linked)
+		}
+
+		return
+	}
+
+	backLinks := back(other.entity.(*model.Tag))
+	if slices.Contains(*backLinks, f.uri.Raw) {
+		return
+	}
+
+	c.fixable(SeverityError, CodeHierarchyAsymmetry, f, other, func() { *backLinks = append(*backLinks, f.uri.Raw) },
+		"%s lists %s as %s, but %s does not list it as %s", f.uri.Raw, linked, field, linked, backField)
+}
+
+func (c *checker) checkRelationType(f *file, r *model.RelationType) {
+	if r.InverseOf == "" {
+		return
+	}
+
+	other, ok := c.relTypes[r.InverseOf]
+	if !ok {
+		if !c.exists(r.InverseOf, model.EntityTypeRelation) {
+			c.found(SeverityError, CodeInverseMismatch, f, c.suggest(r.InverseOf, model.EntityTypeRelation),
+				"inverse relation type %s does not exist", r.InverseOf)
+		}
+
+		return
+	}
+
+	inverse := other.entity.(*model.RelationType)
+
+	switch inverse.InverseOf {
+	case f.uri.Raw:
+	case "":
+		c.fixable(SeverityError, CodeInverseMismatch, f, other, func() { inverse.InverseOf = f.uri.Raw },
+			"%s is the inverse of %s, but %s names no inverse", f.uri.Raw, r.InverseOf, r.InverseOf)
+	default:
+		c.found(SeverityError, CodeInverseMismatch, f,
+			fmt.Sprintf("Decide which of %s and %s is the inverse of %s and fix the other", f.uri.Raw, inverse.InverseOf, r.InverseOf),
+			"%s is the inverse of %s, but %s names %s as its inverse", f.uri.Raw, r.InverseOf, r.InverseOf, inverse.InverseOf)
+	}
+}
+
+// exists reports whether the file of the entityType entity at raw exists,
+// even if it could not be parsed. Unparsable files are reported on their own.
+func (c *checker) exists(raw, entityType string) bool {
+	u, err := uri.Parse(raw)
+	return err == nil && u.Entity == entityType && c.existing[raw]
+}
+
+func parseEntity(entityType, content string) (any, error) {
+	switch entityType {
+	case model.EntityTypeTag:
+		return model.ParseTag(content)
+	case model.EntityTypeRelation:
+		return model.ParseRelationType(content)
+	case model.EntityTypeContext:
+		return model.ParseContext(content)
+	case model.EntityTypeDomain:
+		return model.ParseDomain(content)
+	default:
+		return model.ParseConcept(content)
+	}
+}
+
+func encodeEntity(e any) (string, error) {
+	switch v := e.(type) {
+	case *model.Tag:
+		return model.EncodeTag(v)
+	case *model.RelationType:
+		return model.EncodeRelationType(v)
+	case *model.Context:
+		return model.EncodeContext(v)
+	case *model.Domain:
+		return model.EncodeDomain(v)
+	default:
+		return model.EncodeConcept(v.(*model.Concept))
+	}
+}
+
+func setURI(e any, raw string) {
+	switch v := e.(type) {
+	case *model.Tag:
+		v.URI = raw
+	case *model.RelationType:
+		v.URI = raw
+	case *model.Context:
+		v.URI = raw
+	case *model.Domain:
+		v.URI = raw
+	case *model.Concept:
+		v.URI = raw
+	}
+}
+
+// touch increments the version of e and sets its last update to now,
+// returning the new version.
+func touch(e any, now time.Time) int {
+	switch v := e.(type) {
+	case *model.Tag:
+		v.Version, v.LastUpdate = v.Version+1, now
+		return v.Version
+	case *model.RelationType:
+		v.Version, v.LastUpdate = v.Version+1, now
+		return v.Version
+	case *model.Context:
+		v.Version, v.LastUpdate = v.Version+1, now
+		return v.Version
+	case *model.Domain:
+		v.Version, v.LastUpdate = v.Version+1, now
+		return v.Version
+	default:
+		c := v.(*model.Concept)
+		c.Version, c.LastUpdate = c.Version+1, now
+		return c.Version
+	}
+}
+
+// allEntityTypes is what the encoders write for a missing list of allowed
+// entity types.
+var allEntityTypes = []string{model.EntityTypeContext, model.EntityTypeDomain, model.EntityTypeConcept}
+
+// checkLists reports the lists of f that are missing, and therefore written
+// by the encoders as their default, and the entries listed more than once.
+func (c *checker) checkLists(f *file) {
+	switch e := f.entity.(type) {
+	case *model.Tag:
+		missingList(c, f, "allowed-entities", &e.AllowedEntities, allEntityTypes)
+		missingList(c, f, "broader", &e.Broader, []string{})
+		missingList(c, f, "narrower", &e.Narrower, []string{})
+		duplicates(c, f, "broader", &e.Broader)
+		duplicates(c, f, "narrower", &e.Narrower)
+	case *model.RelationType:
+		missingList(c, f, "allowed-source-entities", &e.AllowedSourceEntities, allEntityTypes)
+		missingList(c, f, "allowed-target-entities", &e.AllowedTargetEntities, allEntityTypes)
+	case *model.Context:
+		c.checkTaggedLists(f, &e.Tags, &e.Relations)
+	case *model.Domain:
+		c.checkTaggedLists(f, &e.Tags, &e.Relations)
+	case *model.Concept:
+		c.checkTaggedLists(f, &e.Tags, &e.Relations)
+		missingList(c, f, "sources", &e.Sources, []model.Source{})
+		duplicates(c, f, "sources", &e.Sources)
+	}
+}
+
+func (c *checker) checkTaggedLists(f *file, tags *[]string, relations *[]model.RelationRef) {
+	missingList(c, f, "tags", tags, []string{})
+	missingList(c, f, "relations", relations, []model.RelationRef{})
+	duplicates(c, f, "tags", tags)
+	duplicates(c, f, "relations", relations)
+}
+
+func missingList[T any](c *checker, f *file, field string, list *[]T, defaults []T) {
+	if *list != nil {
+		return
+	}
+
+	description := "an empty list"
+	if len(defaults) > 0 {
+		description = fmt.Sprintf("%v", defaults)
+	}
+
+	c.fixable(SeverityWarning, CodeMissingList, f, f, func() { *list = slices.Clone(defaults) },
+		"%s is missing and defaults to %s", field, description)
+}
+
+// duplicates reports every entry of list that is listed more than once. The
+// repair keeps the first occurrence of each entry.
+func duplicates[T comparable](c *checker, f *file, field string, list *[]T) {
+	seen := make(map[T]int, len(*list))
+
+	for _, entry := range *list {
+		seen[entry]++
+
+		if seen[entry] != 2 {
+			continue
+		}
+
+		c.fixable(SeverityWarning, CodeDuplicate, f, f, func() { *list = unique(*list) },
+			"%s lists %v more than once", field, entry)
+	}
+}
+
+func unique[T comparable](list []T) []T {
+	seen := make(map[T]bool, len(list))
+	kept := make([]T, 0, len(list))
+
+	for _, entry := range list {
+		if !seen[entry] {
+			seen[entry] = true
+			kept = append(kept, entry)
+		}
+	}
+
+	return kept
+}
+
+// suggest tells how to solve a reference to raw, the URI of a missing
+// entityType entity, or of any entity type when entityType is empty: by
+// following the redirect left behind when it was moved, by using the
+// closest existing URI, or by creating the entity.
+func (c *checker) suggest(raw, entityType string) string {
+	if to, ok := c.redirects[raw]; ok && c.existing[to] {
+		return fmt.Sprintf("Replace it with %s, where %s was moved", to, raw)
+	}
+
+	u, err := uri.Parse(raw)
+	if err != nil {
+		return "Replace it with a valid URI or remove the reference"
+	}
+
+	if entityType == "" {
+		entityType = u.Entity
+	}
+
+	var candidates []*uri.URI
+
+	for existing := range c.existing {
+		if other, err := uri.Parse(existing); err == nil && other.Entity == entityType {
+			candidates = append(candidates, other)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Raw < candidates[j].Raw })
+
+	slugs := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		slugs[i] = candidate.Slug
+	}
+
+	if matches := fuzzy.Find(u.Slug, slugs); len(matches) > 0 {
+		closest := candidates[matches[0].Index].Raw
+		return fmt.Sprintf("Replace it with %s, the closest existing %s, or create %s", closest, entityType, raw)
+	}
+
+	return fmt.Sprintf("Create %s or remove the reference", raw)
+}
+
+// save writes every repaired file with its version incremented and now as
+// its last update, keeping a snapshot of the new version before writing it
+// and committing it to repo when there is one. A file that fails is
+// reported and left out, and the others are still saved.
+func (c *checker) save(now time.Time, repo *git.Repo) {
+	for _, f := range c.files {
+		if !f.changed {
+			continue
+		}
+
+		version, err := c.saveFile(f, now)
+		if err != nil {
+			for _, i := range c.repairs[f] {
+				c.report.Problems[i].Fixed = false
+			}
+
+			c.found(SeverityError, CodeFixFailed, f, "", "%v", err)
+			continue
+		}
+
+		c.report.Repaired = append(c.report.Repaired, f.path)
+
+		if repo != nil {
+			message := git.Message(git.OpFix, f.uri.Raw, version-1, version, repo.Actor())
+
+			if err := repo.Commit(message, filepath.FromSlash(f.path)); err != nil {
+				c.found(SeverityError, CodeFixFailed, f, "Commit the repaired file by hand",
+					"repaired but not committed: %v", err)
+			}
+		}
+	}
+
+	sort.Strings(c.report.Repaired)
+}
+
+// saveFile writes the repaired entity of f, keeping a snapshot of its new
+// version first, and returns that version.
+func (c *checker) saveFile(f *file, now time.Time) (int, error) {
+	version := touch(f.entity, now)
+
+	content, err := encodeEntity(f.entity)
+	if err != nil {
+		return 0, fmt.Errorf("failed to repair %s: %w", f.path, err)
+	}
+
+	if err := storage.SaveSnapshot(c.rootDir, f.uri, version, []byte(content)); err != nil {
+		return 0, fmt.Errorf("failed to keep history of %s: %w", f.path, err)
+	}
+
+	if err := storage.SaveFile(c.rootDir, f.uri, []byte(content)); err != nil {
+		return 0, fmt.Errorf("failed to repair %s: %w", f.path, err)
+	}
+
+	return version, nil
+}
@@ -0,0 +1,369 @@
+package check_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/check"
+	"github.com/jjmrocha/knowledge-mcp/internal/git"
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+const (
+	contextURI  = "scio://contexts/ecommerce"
+	domainURI   = "scio://contexts/ecommerce/domains/pricing"
+	conceptURI  = "scio://contexts/ecommerce/domains/pricing/concepts/discount"
+	pricingTag  = "scio://tags/pricing"
+	discountTag = "scio://tags/discount"
+	partOf      = "scio://relations/part-of"
+	hasPart     = "scio://relations/has-part"
+)
+
+func save(t *testing.T, root string, e any) {
+	t.Helper()
+
+	var (
+		raw     string
+		content string
+		err     error
+	)
+
+	switch v := e.(type) {
+	case *model.Tag:
+		v.Entity, v.Schema, v.Version, raw = model.EntityTypeTag, model.SchemaVersion, 1, v.URI
+		content, err = model.EncodeTag(v)
+	case *model.RelationType:
+		v.Entity, v.Schema, v.Version, raw = model.EntityTypeRelation, model.SchemaVersion, 1, v.URI
+		content, err = model.EncodeRelationType(v)
+	case *model.Context:
+		v.Entity, v.Schema, v.Version, raw = model.EntityTypeContext, model.SchemaVersion, 1, v.URI
+		content, err = model.EncodeContext(v)
+	case *model.Domain:
+		v.Entity, v.Schema, v.Version, raw = model.EntityTypeDomain, model.SchemaVersion, 1, v.URI
+		content, err = model.EncodeDomain(v)
+	case *model.Concept:
+		v.Entity, v.Schema, v.Version, raw = model.EntityTypeConcept, model.SchemaVersion, 1, v.URI
+		content, err = model.EncodeConcept(v)
+	}
+
+	require.NoError(t, err)
+	writeFile(t, root, raw, content)
+}
+
+func writeFile(t *testing.T, root, raw, content string) {
+	t.Helper()
+
+	u, err := uri.Parse(raw)
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveFile(root, u, []byte(content)))
+}
+
+// newStore returns the root of a consistent store.
+func newStore(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	require.NoError(t, storage.InitRootDirs(root))
+
+	save(t, root, &model.Tag{URI: pricingTag, Narrower: []string{discountTag}})
+	save(t, root, &model.Tag{URI: discountTag, Broader: []string{pricingTag}})
+	save(t, root, &model.RelationType{URI: partOf, InverseOf: hasPart})
+	save(t, root, &model.RelationType{URI: hasPart, InverseOf: partOf})
+	save(t, root, &model.Context{URI: contextURI})
+	save(t, root, &model.Domain{URI: domainURI, Relations: []model.RelationRef{{Type: partOf, Target: contextURI}}})
+	save(t, root, &model.Concept{URI: conceptURI, Tags: []string{discountTag}, Relations: []model.RelationRef{{Type: partOf, Target: domainURI}}})
+
+	return root
+}
+
+// codes returns the code of every problem keyed by path.
+func codes(report *check.Report) map[string][]string {
+	found := make(map[string][]string)
+
+	for _, p := range report.Problems {
+		found[p.Path] = append(found[p.Path], p.Code)
+	}
+
+	return found
+}
+
+func TestRun_Clean(t *testing.T) {
+	// given
+	root := newStore(t)
+	require.NoError(t, storage.SaveRedirects(root, []byte("schema: 1\nredirects: []\n")))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".gitignore"), []byte(".index.db*\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".index.db-wal"), nil, 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git", "objects"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".git", "objects", "ab"), nil, 0o600))
+	u, err := uri.Parse(conceptURI)
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveSnapshot(root, u, 1, []byte("old")))
+	// when
+	report, err := check.Run(root)
+	// then
+	require.NoError(t, err)
+	assert.False(t, report.Failed())
+	assert.Equal(t, 7, report.Files)
+	assert.Empty(t, report.Problems)
+}
+
+func TestRun_FileProblems(t *testing.T) {
+	// given
+	root := newStore(t)
+	writeFile(t, root, domainURI+"/concepts/broken", "no frontmatter")
+	save(t, root, &model.Concept{URI: domainURI + "/concepts/elsewhere"})
+	require.NoError(t, os.Rename(
+		filepath.Join(root, "contexts/ecommerce/domains/pricing/elsewhere.md"),
+		filepath.Join(root, "contexts/ecommerce/domains/pricing/moved.md"),
+	))
+	save(t, root, &model.Tag{URI: "scio://tags/misplaced"})
+	require.NoError(t, os.Rename(
+		filepath.Join(root, "tags/misplaced.md"),
+		filepath.Join(root, "relations/misplaced.md"),
+	))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "contexts/ecommerce/notes.txt"), []byte("todo"), 0o600))
+	// when
+	report, err := check.Run(root)
+	// then
+	require.NoError(t, err)
+	assert.True(t, report.Failed())
+	assert.Equal(t, 3, report.Errors)
+	assert.Equal(t, 1, report.Warnings)
+	assert.Equal(t, map[string][]string{
+		"contexts/ecommerce/domains/pricing/broken.md": {check.CodeParseFailed},
+		"contexts/ecommerce/domains/pricing/moved.md":  {check.CodeURIMismatch},
+		"contexts/ecommerce/notes.txt":                 {check.CodeStrayFile},
+		"relations/misplaced.md":                       {check.CodeEntityMismatch},
+	}, codes(report))
+
+	for _, p := range report.Problems {
+		if p.Code == check.CodeURIMismatch {
+			assert.Equal(t, domainURI+"/concepts/moved", p.URI)
+		}
+	}
+}
+
+func TestRun_ReferenceProblems(t *testing.T) {
+	// given
+	root := newStore(t)
+	save(t, root, &model.Tag{URI: pricingTag})
+	save(t, root, &model.RelationType{URI: hasPart})
+	save(t, root, &model.Concept{
+		URI:  conceptURI,
+		Tags: []string{discountTag, "scio://tags/missing"},
+		Relations: []model.RelationRef{
+			{Type: partOf, Target: domainURI + "/concepts/gone"},
+			{Type: "scio://relations/unknown", Target: domainURI},
+		},
+	})
+	// when
+	report, err := check.Run(root)
+	// then
+	require.NoError(t, err)
+	assert.True(t, report.Failed())
+	assert.Equal(t, map[string][]string{
+		"contexts/ecommerce/domains/pricing/discount.md": {check.CodeUnknownTag, check.CodeDanglingTarget, check.CodeUnknownRelation},
+		"relations/part-of.md":                           {check.CodeInverseMismatch},
+		"tags/discount.md":                               {check.CodeHierarchyAsymmetry},
+	}, codes(report))
+}
+
+func TestRun_Suggestions(t *testing.T) {
+	// given
+	root := newStore(t)
+	require.NoError(t, storage.SaveRedirects(root, []byte(
+		"schema: 1\nredirects:\n  - from: "+domainURI+"/concepts/rebate\n    to: "+conceptURI+"\n    reason: moved\n    created: 2026-01-01T00:00:00Z\n")))
+	save(t, root, &model.Concept{
+		URI: domainURI + "/concepts/voucher",
+		Relations: []model.RelationRef{
+			{Type: partOf, Target: domainURI + "/concepts/rebate"},
+			{Type: partOf, Target: domainURI + "/concepts/discnt"},
+			{Type: partOf, Target: domainURI + "/concepts/zzz"},
+		},
+	})
+	// when
+	report, err := check.Run(root)
+	// then
+	require.NoError(t, err)
+
+	var suggestions []string
+	for _, p := range report.Problems {
+		suggestions = append(suggestions, p.Suggestion)
+	}
+
+	assert.Equal(t, []string{
+		"Replace it with " + conceptURI + ", where " + domainURI + "/concepts/rebate was moved",
+		"Replace it with " + conceptURI + ", the closest existing concept, or create " + domainURI + "/concepts/discnt",
+		"Create " + domainURI + "/concepts/zzz or remove the reference",
+	}, suggestions)
+}
+
+func TestFix(t *testing.T) {
+	// given
+	root := newStore(t)
+	save(t, root, &model.Tag{URI: pricingTag})
+	save(t, root, &model.RelationType{URI: hasPart})
+	save(t, root, &model.Concept{
+		URI:       conceptURI,
+		Tags:      []string{discountTag, discountTag},
+		Relations: []model.RelationRef{{Type: partOf, Target: domainURI}, {Type: partOf, Target: domainURI}},
+		Sources:   []model.Source{{Type: "doc", Href: "a.md"}, {Type: "doc", Href: "a.md"}},
+	})
+	writeFile(t, root, domainURI+"/concepts/voucher", "---\nentity: concept\nschema: 1\nuri: "+domainURI+
+		"/concepts/coupon\nversion: 3\ntags: []\nrelations:\n  - type: "+partOf+"\n    target: "+domainURI+"/concepts/gone\n---\nBody\n")
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	// when
+	report, err := check.Fix(root, now, nil)
+	// then
+	require.NoError(t, err)
+	assert.True(t, report.Failed())
+	assert.Equal(t, 1, report.Errors)
+	assert.Equal(t, 0, report.Warnings)
+	assert.Equal(t, 7, report.Fixed)
+	assert.Equal(t, []string{
+		"contexts/ecommerce/domains/pricing/discount.md",
+		"contexts/ecommerce/domains/pricing/voucher.md",
+		"relations/has-part.md",
+		"tags/pricing.md",
+	}, report.Repaired)
+
+	for _, p := range report.Problems {
+		assert.Equal(t, p.Code != check.CodeDanglingTarget, p.Fixed, p.Message)
+	}
+
+	content, err := os.ReadFile(filepath.Join(root, "contexts/ecommerce/domains/pricing/voucher.md"))
+	require.NoError(t, err)
+	voucher, err := model.ParseConcept(string(content))
+	require.NoError(t, err)
+	assert.Equal(t, domainURI+"/concepts/voucher", voucher.URI)
+	assert.Equal(t, 4, voucher.Version)
+	assert.Equal(t, now, voucher.LastUpdate)
+	assert.Equal(t, []model.Source{}, voucher.Sources)
+	assert.Equal(t, "Body\n", voucher.Body)
+
+	content, err = os.ReadFile(filepath.Join(root, "contexts/ecommerce/domains/pricing/discount.md"))
+	require.NoError(t, err)
+	discount, err := model.ParseConcept(string(content))
+	require.NoError(t, err)
+	assert.Equal(t, 2, discount.Version)
+	assert.Equal(t, []string{discountTag}, discount.Tags)
+	assert.Equal(t, []model.RelationRef{{Type: partOf, Target: domainURI}}, discount.Relations)
+	assert.Equal(t, []model.Source{{Type: "doc", Href: "a.md"}}, discount.Sources)
+
+	u, err := uri.Parse(conceptURI)
+	require.NoError(t, err)
+	snapshot, err := storage.ReadSnapshot(root, u, 2)
+	require.NoError(t, err)
+	assert.Equal(t, string(content), string(snapshot))
+
+	recheck, err := check.Run(root)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"contexts/ecommerce/domains/pricing/voucher.md": {check.CodeDanglingTarget},
+	}, codes(recheck))
+}
+
+func TestFix_ConflictingInverse(t *testing.T) {
+	// given
+	root := newStore(t)
+	save(t, root, &model.RelationType{URI: "scio://relations/contains", InverseOf: partOf})
+	// when
+	report, err := check.Fix(root, time.Now(), nil)
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, report.Repaired)
+	require.Len(t, report.Problems, 1)
+	assert.Equal(t, check.CodeInverseMismatch, report.Problems[0].Code)
+	assert.False(t, report.Problems[0].Fixed)
+	assert.NotEmpty(t, report.Problems[0].Suggestion)
+}
+
+func TestFix_FailedFileIsReported(t *testing.T) {
+	// given
+	root := newStore(t)
+	save(t, root, &model.Tag{URI: pricingTag})
+	save(t, root, &model.RelationType{URI: hasPart})
+	// a file where the history folder of the tags should be
+	require.NoError(t, os.MkdirAll(filepath.Join(root, storage.HistoryDir), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, storage.HistoryDir, "tags"), nil, 0o600))
+	// when
+	report, err := check.Fix(root, time.Now(), nil)
+	// then
+	require.NoError(t, err)
+	assert.True(t, report.Failed())
+	assert.Equal(t, []string{"relations/has-part.md"}, report.Repaired)
+	assert.Equal(t, 1, report.Fixed)
+	assert.Equal(t, 2, report.Errors)
+	assert.Equal(t, map[string][]string{
+		"relations/part-of.md": {check.CodeInverseMismatch},
+		"tags/discount.md":     {check.CodeHierarchyAsymmetry},
+		"tags/pricing.md":      {check.CodeFixFailed},
+	}, codes(report))
+
+	content, err := os.ReadFile(filepath.Join(root, "tags/pricing.md"))
+	require.NoError(t, err)
+	pricing, err := model.ParseTag(string(content))
+	require.NoError(t, err)
+	assert.Equal(t, 1, pricing.Version)
+	assert.Empty(t, pricing.Narrower)
+}
+
+// gitLog returns the full message of every commit touching path in the
+// repository at dir, newest first, or of every commit when path is empty.
+func gitLog(t *testing.T, dir, path string) []string {
+	t.Helper()
+
+	args := []string{"-C", dir, "log", "--format=%B%x00"}
+	if path != "" {
+		args = append(args, "--", path)
+	}
+
+	out, err := exec.Command("git", args...).Output()
+	require.NoError(t, err)
+
+	var messages []string
+
+	for _, message := range strings.Split(string(out), "\x00") {
+		if message = strings.TrimSpace(message); message != "" {
+			messages = append(messages, message)
+		}
+	}
+
+	return messages
+}
+
+func TestFix_CommitsRepairs(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	// given
+	root := newStore(t)
+	repo, err := git.Open(root, "tester")
+	require.NoError(t, err)
+	require.NoError(t, repo.Commit("initial", "."))
+	save(t, root, &model.Tag{URI: pricingTag})
+	require.NoError(t, repo.Commit("break pricing", "tags"))
+	// when
+	report, err := check.Fix(root, time.Now(), repo)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tags/pricing.md"}, report.Repaired)
+
+	log := gitLog(t, root, "tags/pricing.md")
+	require.Len(t, log, 3)
+	assert.Equal(t, strings.TrimSpace(git.Message(git.OpFix, pricingTag, 1, 2, "tester")), log[0])
+
+	out, err := exec.Command("git", "-C", root, "status", "--porcelain").Output()
+	require.NoError(t, err)
+	assert.Empty(t, string(out))
+}
@@ -0,0 +1,169 @@
+// Package git records changes to the knowledge store as commits in a local
+// git repository, using the git command line.
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	authorEmail = "knowledge-mcp@localhost"
+	ignoreFile  = ".gitignore"
+	// ignored lists the files of the root directory that are never committed:
+	// the index database with its SQLite side files, the entity history and
+	// the store lock.
+	ignored = ".index.db*\n.history/\n.lock\n"
+)
+
+// Operations recorded in commit messages.
+const (
+	OpCreate    = "create"
+	OpUpdate    = "update"
+	OpDelete    = "delete"
+	OpRedirects = "redirects"
+	// OpFix records a repair made by the store checker.
+	OpFix = "fix"
+)
+
+// Repo is the git repository holding the knowledge store root directory,
+// either at its top level or in a subdirectory of its work tree.
+// Commits are authored by the configured actor.
+type Repo struct {
+	dir   string
+	actor string
+}
+
+// Open returns the repository at dir, initializing one if dir is not yet
+// inside a git work tree. The files that are never committed are ignored
+// by a .gitignore in dir, so that they stay out of an enclosing repository
+// too.
+func Open(dir, actor string) (*Repo, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("git history requires the git command: %w", err)
+	}
+
+	r := &Repo{dir: dir, actor: actor}
+
+	if _, err := r.run("rev-parse", "--is-inside-work-tree"); err != nil {
+		if _, err := r.run("init", "--quiet"); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ensureIgnored(filepath.Join(dir, ignoreFile)); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", ignoreFile, err)
+	}
+
+	return r, nil
+}
+
+// ensureIgnored appends to the ignore file at path every ignored pattern it
+// does not list yet, creating the file if needed.
+func ensureIgnored(path string) error {
+	content, err := os.ReadFile(path) //nolint:gosec
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	listed := strings.Split(string(content), "\n")
+
+	var missing strings.Builder
+
+	for _, pattern := range strings.Split(strings.TrimSpace(ignored), "\n") {
+		if !slices.Contains(listed, pattern) {
+			missing.WriteString(pattern + "\n")
+		}
+	}
+
+	if missing.Len() == 0 {
+		return nil
+	}
+
+	if len(content) > 0 && !bytes.HasSuffix(content, []byte("\n")) {
+		content = append(content, '\n')
+	}
+
+	return os.WriteFile(path, append(content, missing.String()...), 0o644) //nolint:gosec
+}
+
+// Actor is the name commits are authored with.
+func (r *Repo) Actor() string {
+	return r.actor
+}
+
+// Commit records the current content of paths, relative to the knowledge
+// store root directory, with message, leaving anything else staged out of
+// the commit. Paths that no longer exist are recorded as removed, and
+// directories with everything below them. Nothing is committed when the
+// paths did not change.
+func (r *Repo) Commit(message string, paths ...string) error {
+	for _, path := range paths {
+		var err error
+
+		if _, statErr := os.Stat(filepath.Join(r.dir, path)); statErr == nil {
+			_, err = r.run("add", "--all", "--", path)
+		} else {
+			_, err = r.run("rm", "-r", "--cached", "--ignore-unmatch", "--quiet", "--", path)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := r.run(append([]string{"diff", "--cached", "--quiet", "--"}, paths...)...); err == nil {
+		return nil
+	}
+
+	// only the given paths are committed, whatever else is staged
+	args := append([]string{"commit", "--quiet", "--no-verify", "--only", "--message", message, "--"}, paths...)
+	_, err := r.run(args...)
+	return err
+}
+
+// Message builds the message of the commit recording the operation op on
+// one entity. The subject summarises the change and the trailers hold each
+// field for tools reading the history.
+func Message(op, rawURI string, oldVersion, newVersion int, actor string) string {
+	var subject string
+
+	switch op {
+	case OpCreate:
+		subject = fmt.Sprintf("create %s (v%d)", rawURI, newVersion)
+	case OpDelete:
+		subject = fmt.Sprintf("delete %s (v%d)", rawURI, oldVersion)
+	default:
+		subject = fmt.Sprintf("%s %s (v%d -> v%d)", op, rawURI, oldVersion, newVersion)
+	}
+
+	return fmt.Sprintf("%s\n\nOperation: %s\nURI: %s\nOld-Version: %d\nNew-Version: %d\nActor: %s\n",
+		subject, op, rawURI, oldVersion, newVersion, actor)
+}
+
+func (r *Repo) run(args ...string) (string, error) {
+	cmd := exec.Command("git", args...) //nolint:gosec
+	cmd.Dir = r.dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME="+r.actor,
+		"GIT_AUTHOR_EMAIL="+authorEmail,
+		"GIT_COMMITTER_NAME="+r.actor,
+		"GIT_COMMITTER_EMAIL="+authorEmail,
+	)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
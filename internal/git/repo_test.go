@@ -0,0 +1,204 @@
+package git_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/git"
+)
+
+func openRepo(t *testing.T) (*git.Repo, string) {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	repo, err := git.Open(dir, "tester")
+	require.NoError(t, err)
+
+	return repo, dir
+}
+
+// gitLog returns the full message of every commit touching path in the
+// repository at dir, newest first, or of every commit when path is empty.
+func gitLog(t *testing.T, dir, path string) []string {
+	t.Helper()
+
+	args := []string{"-C", dir, "log", "--format=%B%x00"}
+	if path != "" {
+		args = append(args, "--", path)
+	}
+
+	out, err := exec.Command("git", args...).Output()
+	require.NoError(t, err)
+
+	var messages []string
+
+	for _, message := range strings.Split(string(out), "\x00") {
+		if message = strings.TrimSpace(message); message != "" {
+			messages = append(messages, message)
+		}
+	}
+
+	return messages
+}
+
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestOpen_InitializesRepository(t *testing.T) {
+	// given
+	_, dir := openRepo(t)
+	// then
+	assert.DirExists(t, filepath.Join(dir, ".git"))
+	assert.FileExists(t, filepath.Join(dir, ".gitignore"))
+
+	// when — opening again reuses the repository
+	_, err := git.Open(dir, "tester")
+	// then
+	assert.NoError(t, err)
+}
+
+func TestCommit(t *testing.T) {
+	// given
+	repo, dir := openRepo(t)
+	write(t, dir, "tags/pricing.md", "v1")
+	// when
+	require.NoError(t, repo.Commit("create pricing", "tags/pricing.md"))
+	write(t, dir, "tags/pricing.md", "v2")
+	require.NoError(t, repo.Commit("update pricing", "tags/pricing.md"))
+	// then
+	log := gitLog(t, dir, "tags/pricing.md")
+	assert.Equal(t, []string{"update pricing", "create pricing"}, log)
+}
+
+func TestCommit_Unchanged(t *testing.T) {
+	// given
+	repo, dir := openRepo(t)
+	write(t, dir, "tags/pricing.md", "v1")
+	require.NoError(t, repo.Commit("create pricing", "tags/pricing.md"))
+	// when
+	err := repo.Commit("nothing", "tags/pricing.md")
+	// then
+	require.NoError(t, err)
+	log := gitLog(t, dir, "")
+	assert.Equal(t, []string{"create pricing"}, log)
+}
+
+func TestCommit_Removed(t *testing.T) {
+	// given
+	repo, dir := openRepo(t)
+	write(t, dir, "contexts/shop/context.md", "context")
+	write(t, dir, "contexts/shop/domains/cart/domain.md", "domain")
+	require.NoError(t, repo.Commit("create shop", "contexts/shop"))
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "contexts/shop")))
+	// when
+	err := repo.Commit("delete shop", "contexts/shop")
+	// then
+	require.NoError(t, err)
+	log := gitLog(t, dir, "contexts/shop/domains/cart/domain.md")
+	assert.Equal(t, []string{"delete shop", "create shop"}, log)
+}
+
+func TestCommit_NeverTracked(t *testing.T) {
+	// given
+	repo, _ := openRepo(t)
+	// when
+	err := repo.Commit("delete ghost", "tags/ghost.md")
+	// then
+	assert.NoError(t, err)
+}
+
+func TestCommit_IgnoresIndex(t *testing.T) {
+	// given
+	repo, dir := openRepo(t)
+	write(t, dir, ".index.db", "sqlite")
+	// when
+	err := repo.Commit("add everything", ".")
+	// then
+	require.NoError(t, err)
+	out, err := exec.Command("git", "-C", dir, "ls-files").Output()
+	require.NoError(t, err)
+	assert.Equal(t, ".gitignore\n", string(out))
+}
+
+// openNested returns a repository for a store in a subdirectory of an
+// existing work tree, and the top level of that work tree.
+func openNested(t *testing.T) (*git.Repo, string) {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	top := t.TempDir()
+	require.NoError(t, exec.Command("git", "-C", top, "init", "--quiet").Run())
+	require.NoError(t, os.MkdirAll(filepath.Join(top, "kb"), 0o755))
+
+	repo, err := git.Open(filepath.Join(top, "kb"), "tester")
+	require.NoError(t, err)
+
+	return repo, top
+}
+
+func TestOpen_Nested(t *testing.T) {
+	// given
+	_, top := openNested(t)
+	write(t, top, "kb/.index.db", "sqlite")
+	write(t, top, "kb/.history/tags/pricing/1.md", "v1")
+	write(t, top, "kb/.lock", "")
+	// when
+	out, err := exec.Command("git", "-C", top, "status", "--porcelain", "--untracked-files=all").Output()
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "?? kb/.gitignore\n", string(out))
+}
+
+func TestOpen_KeepsIgnoreFile(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	// given
+	dir := t.TempDir()
+	write(t, dir, ".gitignore", "*.bak")
+	// when
+	_, err := git.Open(dir, "tester")
+	// then
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	require.NoError(t, err)
+	assert.Equal(t, "*.bak\n.index.db*\n.history/\n.lock\n", string(content))
+}
+
+func TestCommit_OnlyGivenPaths(t *testing.T) {
+	// given
+	repo, top := openNested(t)
+	write(t, top, "unrelated.txt", "staged by the user")
+	require.NoError(t, exec.Command("git", "-C", top, "add", "unrelated.txt").Run())
+	write(t, top, "kb/contexts/a/context.md", "context")
+	// when
+	err := repo.Commit("create a", "contexts/a/context.md")
+	// then
+	require.NoError(t, err)
+	out, err := exec.Command("git", "-C", top, "show", "--name-only", "--format=", "HEAD").Output()
+	require.NoError(t, err)
+	assert.Equal(t, "kb/contexts/a/context.md\n", string(out))
+
+	out, err = exec.Command("git", "-C", top, "diff", "--cached", "--name-only").Output()
+	require.NoError(t, err)
+	assert.Equal(t, "unrelated.txt\n", string(out))
+}
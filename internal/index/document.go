@@ -0,0 +1,117 @@
+package index
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// document is the indexed content of one entity file.
+type document struct {
+	name       string
+	version    int
+	lastUpdate time.Time
+	tags       []string
+	relations  []model.RelationRef
+	// body is only set for the entities that are full-text indexed.
+	body       string
+	searchable bool
+}
+
+// parseDocument parses content with the model parser matching the entity
+// type of u.
+func parseDocument(u *uri.URI, content string) (*document, error) {
+	switch u.Entity {
+	case model.EntityTypeTag:
+		t, err := model.ParseTag(content)
+		if err != nil {
+			return nil, err
+		}
+
+		return &document{version: t.Version, lastUpdate: t.LastUpdate}, nil
+	case model.EntityTypeRelation:
+		r, err := model.ParseRelationType(content)
+		if err != nil {
+			return nil, err
+		}
+
+		return &document{version: r.Version, lastUpdate: r.LastUpdate}, nil
+	case model.EntityTypeContext:
+		c, err := model.ParseContext(content)
+		if err != nil {
+			return nil, err
+		}
+
+		return &document{
+			name:       c.Name,
+			version:    c.Version,
+			lastUpdate: c.LastUpdate,
+			tags:       c.Tags,
+			relations:  c.Relations,
+			body:       c.Body,
+			searchable: true,
+		}, nil
+	case model.EntityTypeDomain:
+		d, err := model.ParseDomain(content)
+		if err != nil {
+			return nil, err
+		}
+
+		return &document{
+			name:       d.Name,
+			version:    d.Version,
+			lastUpdate: d.LastUpdate,
+			tags:       d.Tags,
+			relations:  d.Relations,
+			body:       d.Body,
+			searchable: true,
+		}, nil
+	case model.EntityTypeConcept:
+		c, err := model.ParseConcept(content)
+		if err != nil {
+			return nil, err
+		}
+
+		return &document{
+			name:       c.Name,
+			version:    c.Version,
+			lastUpdate: c.LastUpdate,
+			tags:       c.Tags,
+			relations:  c.Relations,
+			body:       c.Body,
+			searchable: true,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported entity type %q", u.Entity)
+	}
+}
+
+// contextOf returns the context an entity belongs to; a context belongs to
+// itself.
+func contextOf(u *uri.URI) string {
+	if u.Entity == model.EntityTypeContext {
+		return u.Slug
+	}
+
+	if u.Context == nil {
+		return ""
+	}
+
+	return *u.Context
+}
+
+// domainOf returns the domain an entity belongs to; a domain belongs to
+// itself.
+func domainOf(u *uri.URI) string {
+	if u.Entity == model.EntityTypeDomain {
+		return u.Slug
+	}
+
+	if u.Domain == nil {
+		return ""
+	}
+
+	return *u.Domain
+}
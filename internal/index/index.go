@@ -0,0 +1,448 @@
+// Package index maintains a SQLite index of every entity in the knowledge
+// store, so that listing and reverse lookups do not need to walk and parse
+// the whole directory tree.
+package index
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	_ "modernc.org/sqlite" // registers the sqlite driver
+
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// FileName is the name of the index file, created in the root directory of
+// the store.
+const FileName = ".index.db"
+
+// Index is the SQLite index of a knowledge store.
+type Index struct {
+	rootDir string
+	db      *sql.DB
+}
+
+// Open opens the index of the store at rootDir, rebuilding it from the
+// entity files if it is missing, was written by another schema version or
+// no longer matches the files on disk. The index only caches what the files
+// hold, so an index file that cannot be read is removed and built again.
+func Open(rootDir string) (*Index, error) {
+	idx, err := open(rootDir)
+	if err == nil {
+		return idx, nil
+	}
+
+	log.Printf("warning: rebuilding the unreadable index of %s: %v", rootDir, err)
+
+	if err := removeFiles(rootDir); err != nil {
+		return nil, err
+	}
+
+	return open(rootDir)
+}
+
+func open(rootDir string) (*Index, error) {
+	dsn := "file:" + filepath.Join(rootDir, FileName) + "?_pragma=busy_timeout(5000)"
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index: %w", err)
+	}
+
+	db.SetMaxOpenConns(1)
+	idx := &Index{rootDir: rootDir, db: db}
+
+	stale, err := idx.stale()
+	if err == nil && stale {
+		err = idx.Rebuild()
+	}
+
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+// removeFiles removes the index file of the store at rootDir together with
+// the side files SQLite keeps next to it.
+func removeFiles(rootDir string) error {
+	for _, suffix := range []string{"", "-wal", "-shm", "-journal"} {
+		err := os.Remove(filepath.Join(rootDir, FileName+suffix))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to remove index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (i *Index) Close() error {
+	return i.db.Close()
+}
+
+// Rebuild discards the index content and indexes every entity file again.
+func (i *Index) Rebuild() error {
+	files, err := storage.ScanEntityFiles(i.rootDir)
+	if err != nil {
+		return err
+	}
+
+	return i.inTx(func(tx *sql.Tx) error {
+		for _, stmt := range dropStatements {
+			if _, err := tx.Exec(stmt); err != nil {
+				return err
+			}
+		}
+
+		for _, stmt := range createStatements {
+			if _, err := tx.Exec(stmt); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", schemaVersion)); err != nil {
+			return err
+		}
+
+		for path, info := range files {
+			content, err := os.ReadFile(path) //nolint:gosec
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", path, err)
+			}
+
+			if err := insert(tx, path, info, content); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Update indexes the content just written to the file of u.
+func (i *Index) Update(u *uri.URI, content []byte) error {
+	path := storage.FileName(i.rootDir, u)
+
+	info, err := storage.StatFile(path, u)
+	if err != nil {
+		return fmt.Errorf("failed to index %s: %w", u, err)
+	}
+
+	return i.inTx(func(tx *sql.Tx) error {
+		if err := deleteRows(tx, []string{u.Raw}); err != nil {
+			return err
+		}
+
+		return insert(tx, path, info, content)
+	})
+}
+
+// Remove drops u from the index. Removing a context or domain also drops
+// every entity stored inside it.
+func (i *Index) Remove(u *uri.URI) error {
+	return i.inTx(func(tx *sql.Tx) error {
+		prefix := u.Raw + "/"
+
+		rows, err := tx.Query(
+			`SELECT uri FROM files WHERE uri = ? OR substr(uri, 1, ?) = ?`,
+			u.Raw, len(prefix), prefix,
+		)
+		if err != nil {
+			return err
+		}
+
+		removed, err := scanStrings(rows)
+		if err != nil {
+			return err
+		}
+
+		return deleteRows(tx, removed)
+	})
+}
+
+// Sync indexes the entity files created or changed on disk since they were
+// last indexed and drops the ones removed, such as after edits in an editor
+// or a git pull. files is the state of every entity file, as returned by
+// storage.ScanEntityFiles; as it may have been taken before another process
+// indexed its own writes, every file is checked again before its rows are
+// replaced or dropped. It returns the URIs of the files indexed or dropped,
+// sorted.
+func (i *Index) Sync(files map[string]storage.FileState) ([]string, error) {
+	var changed []string
+
+	err := i.inTx(func(tx *sql.Tx) error {
+		indexed, err := indexedFiles(tx)
+		if err != nil {
+			return err
+		}
+
+		var removed []string
+
+		for path, state := range indexed {
+			if _, ok := files[path]; ok {
+				continue
+			}
+
+			// created again since the scan
+			if _, err := os.Stat(path); err == nil {
+				continue
+			}
+
+			removed = append(removed, state.URI.Raw)
+		}
+
+		if err := deleteRows(tx, removed); err != nil {
+			return err
+		}
+
+		changed = removed
+
+		for path, state := range files {
+			old, ok := indexed[path]
+			if ok && old.Same(state) {
+				continue
+			}
+
+			current, err := storage.StatFile(path, state.URI)
+			if errors.Is(err, fs.ErrNotExist) {
+				// removed since the scan, the next sync drops it
+				continue
+			}
+
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", path, err)
+			}
+
+			if ok && old.Same(current) {
+				// already indexed since the scan
+				continue
+			}
+
+			content, err := os.ReadFile(path) //nolint:gosec
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", path, err)
+			}
+
+			if err := deleteRows(tx, []string{current.URI.Raw}); err != nil {
+				return err
+			}
+
+			if err := insert(tx, path, current, content); err != nil {
+				return err
+			}
+
+			changed = append(changed, current.URI.Raw)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// indexedFiles returns the state of every indexed file when it was indexed,
+// keyed by path.
+func indexedFiles(tx *sql.Tx) (map[string]storage.FileState, error) {
+	rows, err := tx.Query(`SELECT path, uri, mod_time, size FROM files`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read indexed files: %w", err)
+	}
+	defer rows.Close()
+
+	indexed := make(map[string]storage.FileState)
+
+	for rows.Next() {
+		var (
+			path, raw     string
+			modTime, size int64
+		)
+
+		if err := rows.Scan(&path, &raw, &modTime, &size); err != nil {
+			return nil, err
+		}
+
+		u, err := uri.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid indexed URI %q: %w", raw, err)
+		}
+
+		indexed[path] = storage.FileState{URI: u, ModTime: modTime, Size: size}
+	}
+
+	return indexed, rows.Err()
+}
+
+// stale reports whether the index must be rebuilt.
+func (i *Index) stale() (bool, error) {
+	var version int
+	if err := i.db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return false, fmt.Errorf("failed to read index version: %w", err)
+	}
+
+	if version != schemaVersion {
+		return true, nil
+	}
+
+	files, err := storage.ScanEntityFiles(i.rootDir)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := i.db.Query(`SELECT path, mod_time, size FROM files`)
+	if err != nil {
+		return false, fmt.Errorf("failed to read indexed files: %w", err)
+	}
+	defer rows.Close()
+
+	indexed := 0
+
+	for rows.Next() {
+		var (
+			path          string
+			modTime, size int64
+		)
+
+		if err := rows.Scan(&path, &modTime, &size); err != nil {
+			return false, err
+		}
+
+		info, ok := files[path]
+		if !ok || info.ModTime != modTime || info.Size != size {
+			return true, nil
+		}
+
+		indexed++
+	}
+
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+
+	return indexed != len(files), nil
+}
+
+func (i *Index) inTx(apply func(tx *sql.Tx) error) error {
+	tx, err := i.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to start index transaction: %w", err)
+	}
+
+	if err := apply(tx); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to update index: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// insert adds the rows of one entity file. Files that cannot be parsed are
+// only recorded in the files table.
+func insert(tx *sql.Tx, path string, info storage.FileState, content []byte) error {
+	u := info.URI
+
+	if _, err := tx.Exec(
+		`INSERT INTO files (path, uri, entity, mod_time, size) VALUES (?, ?, ?, ?, ?)`,
+		path, u.Raw, u.Entity, info.ModTime, info.Size,
+	); err != nil {
+		return err
+	}
+
+	doc, err := parseDocument(u, string(content))
+	if err != nil {
+		return nil
+	}
+
+	if _, err := tx.Exec(
+		`INSERT INTO entities (uri, entity, context, domain, slug, name, version, last_update) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		u.Raw, u.Entity, contextOf(u), domainOf(u), u.Slug, doc.name, doc.version, doc.lastUpdate.UTC().Format(timeLayout),
+	); err != nil {
+		return err
+	}
+
+	for _, tag := range doc.tags {
+		if _, err := tx.Exec(`INSERT INTO tags (uri, tag) VALUES (?, ?)`, u.Raw, tag); err != nil {
+			return err
+		}
+	}
+
+	for _, ref := range doc.relations {
+		if _, err := tx.Exec(`INSERT INTO relations (source, type, target) VALUES (?, ?, ?)`, u.Raw, ref.Type, ref.Target); err != nil {
+			return err
+		}
+	}
+
+	if !doc.searchable {
+		return nil
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO documents (uri, name, body, tags) VALUES (?, ?, ?, ?)`,
+		u.Raw, doc.name, doc.body, tagSlugs(doc.tags),
+	)
+
+	return err
+}
+
+// tagSlugs returns the slugs of tags separated by spaces, so that tag names
+// are searchable as words.
+func tagSlugs(tags []string) string {
+	slugs := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		slugs = append(slugs, tag[strings.LastIndex(tag, "/")+1:])
+	}
+
+	return strings.Join(slugs, " ")
+}
+
+func deleteRows(tx *sql.Tx, uris []string) error {
+	if len(uris) == 0 {
+		return nil
+	}
+
+	for _, table := range entityTables {
+		filter, args := inClause(table.column, uris)
+
+		if _, err := tx.Exec(`DELETE FROM `+table.name+filter, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanStrings(rows *sql.Rows) ([]string, error) {
+	defer rows.Close()
+
+	var values []string
+
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+
+		values = append(values, v)
+	}
+
+	return values, rows.Err()
+}
@@ -0,0 +1,387 @@
+package index_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/index"
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+const (
+	contextURI = "scio://contexts/ecommerce"
+	domainURI  = "scio://contexts/ecommerce/domains/pricing"
+	conceptURI = "scio://contexts/ecommerce/domains/pricing/concepts/discount"
+	couponURI  = "scio://contexts/ecommerce/domains/pricing/concepts/coupon"
+	pricingTag = "scio://tags/pricing"
+	partOf     = "scio://relations/part-of"
+)
+
+func save(t *testing.T, root, rawURI string, encode func() (string, error)) {
+	t.Helper()
+
+	u, err := uri.Parse(rawURI)
+	require.NoError(t, err)
+
+	content, err := encode()
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveFile(root, u, []byte(content)))
+}
+
+func concept(rawURI string, tags ...string) *model.Concept {
+	return &model.Concept{
+		Entity:  model.EntityTypeConcept,
+		Schema:  model.SchemaVersion,
+		URI:     rawURI,
+		Name:    "Discount",
+		Version: 1,
+		Tags:    tags,
+		Relations: []model.RelationRef{
+			{Type: partOf, Target: domainURI},
+		},
+		Sources: []model.Source{{Type: "url", Href: "https://example.com/discounts"}},
+	}
+}
+
+// newStore returns the root of a store holding a context, a domain, a tag,
+// a relation type and a concept using them.
+func newStore(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	require.NoError(t, storage.InitRootDirs(root))
+
+	save(t, root, pricingTag, func() (string, error) {
+		return model.EncodeTag(&model.Tag{Entity: model.EntityTypeTag, Schema: model.SchemaVersion, URI: pricingTag, Version: 1})
+	})
+	save(t, root, partOf, func() (string, error) {
+		return model.EncodeRelationType(&model.RelationType{Entity: model.EntityTypeRelation, Schema: model.SchemaVersion, URI: partOf, Version: 1})
+	})
+	save(t, root, contextURI, func() (string, error) {
+		return model.EncodeContext(&model.Context{Entity: model.EntityTypeContext, Schema: model.SchemaVersion, URI: contextURI, Name: "E-commerce", Version: 1})
+	})
+	save(t, root, domainURI, func() (string, error) {
+		return model.EncodeDomain(&model.Domain{Entity: model.EntityTypeDomain, Schema: model.SchemaVersion, URI: domainURI, Name: "Pricing", Version: 1})
+	})
+	save(t, root, conceptURI, func() (string, error) {
+		return model.EncodeConcept(concept(conceptURI, pricingTag))
+	})
+
+	return root
+}
+
+func open(t *testing.T, root string) *index.Index {
+	t.Helper()
+
+	idx, err := index.Open(root)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = idx.Close() })
+	return idx
+}
+
+func TestOpen_BuildsIndex(t *testing.T) {
+	// given
+	root := newStore(t)
+	// when
+	idx := open(t, root)
+	// then
+	assert.FileExists(t, filepath.Join(root, index.FileName))
+
+	uris, err := idx.URIs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{contextURI, domainURI, conceptURI, partOf, pricingTag}, uris)
+
+	concepts, err := idx.URIs(model.EntityTypeConcept, model.EntityTypeDomain)
+	require.NoError(t, err)
+	assert.Equal(t, []string{domainURI, conceptURI}, concepts)
+
+	tagged, err := idx.Tagged(pricingTag)
+	require.NoError(t, err)
+	assert.Equal(t, []string{conceptURI}, tagged)
+
+	related, err := idx.RelatedBy(partOf)
+	require.NoError(t, err)
+	assert.Equal(t, []string{conceptURI}, related)
+
+	incoming, err := idx.Incoming(domainURI)
+	require.NoError(t, err)
+	assert.Equal(t, []index.Edge{{Source: conceptURI, Type: partOf, Target: domainURI}}, incoming)
+}
+
+func TestOpen_RebuildsStaleIndex(t *testing.T) {
+	// given
+	root := newStore(t)
+	idx, err := index.Open(root)
+	require.NoError(t, err)
+	require.NoError(t, idx.Close())
+
+	save(t, root, couponURI, func() (string, error) {
+		return model.EncodeConcept(concept(couponURI, pricingTag))
+	})
+
+	u, err := uri.Parse(conceptURI)
+	require.NoError(t, err)
+	require.NoError(t, storage.DeleteFile(root, u))
+	// when
+	idx = open(t, root)
+	// then
+	tagged, err := idx.Tagged(pricingTag)
+	require.NoError(t, err)
+	assert.Equal(t, []string{couponURI}, tagged)
+}
+
+func TestOpen_RebuildsCorruptIndex(t *testing.T) {
+	// given
+	root := newStore(t)
+	require.NoError(t, os.WriteFile(filepath.Join(root, index.FileName), []byte("not a database, just garbage bytes"), 0o600))
+	// when
+	idx := open(t, root)
+	// then
+	tagged, err := idx.Tagged(pricingTag)
+	require.NoError(t, err)
+	assert.Equal(t, []string{conceptURI}, tagged)
+}
+
+func TestOpen_RebuildsChangedFile(t *testing.T) {
+	// given
+	root := newStore(t)
+	idx, err := index.Open(root)
+	require.NoError(t, err)
+	require.NoError(t, idx.Close())
+
+	save(t, root, conceptURI, func() (string, error) {
+		return model.EncodeConcept(concept(conceptURI))
+	})
+
+	u, err := uri.Parse(conceptURI)
+	require.NoError(t, err)
+
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(storage.FileName(root, u), later, later))
+	// when
+	idx = open(t, root)
+	// then
+	tagged, err := idx.Tagged(pricingTag)
+	require.NoError(t, err)
+	assert.Empty(t, tagged)
+}
+
+func TestOpen_UnparsableFileIsListed(t *testing.T) {
+	// given
+	root := newStore(t)
+	u, err := uri.Parse(couponURI)
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveFile(root, u, []byte("not an entity")))
+	// when
+	idx := open(t, root)
+	// then
+	uris, err := idx.URIs(model.EntityTypeConcept)
+	require.NoError(t, err)
+	assert.Equal(t, []string{couponURI, conceptURI}, uris)
+}
+
+func TestUpdate(t *testing.T) {
+	// given
+	root := newStore(t)
+	idx := open(t, root)
+	save(t, root, couponURI, func() (string, error) {
+		return model.EncodeConcept(concept(couponURI, pricingTag))
+	})
+
+	u, err := uri.Parse(couponURI)
+	require.NoError(t, err)
+
+	content, err := storage.ReadFile(root, u)
+	require.NoError(t, err)
+	// when
+	err = idx.Update(u, content)
+	// then
+	require.NoError(t, err)
+
+	tagged, err := idx.Tagged(pricingTag)
+	require.NoError(t, err)
+	assert.Equal(t, []string{couponURI, conceptURI}, tagged)
+
+	outgoing, err := idx.Outgoing(couponURI)
+	require.NoError(t, err)
+	assert.Equal(t, []index.Edge{{Source: couponURI, Type: partOf, Target: domainURI}}, outgoing)
+}
+
+func TestUpdate_ReplacesRows(t *testing.T) {
+	// given
+	root := newStore(t)
+	idx := open(t, root)
+	save(t, root, conceptURI, func() (string, error) {
+		return model.EncodeConcept(concept(conceptURI))
+	})
+
+	u, err := uri.Parse(conceptURI)
+	require.NoError(t, err)
+
+	content, err := storage.ReadFile(root, u)
+	require.NoError(t, err)
+	// when
+	err = idx.Update(u, content)
+	// then
+	require.NoError(t, err)
+
+	tagged, err := idx.Tagged(pricingTag)
+	require.NoError(t, err)
+	assert.Empty(t, tagged)
+}
+
+func TestRemove_Container(t *testing.T) {
+	// given
+	root := newStore(t)
+	idx := open(t, root)
+
+	u, err := uri.Parse(domainURI)
+	require.NoError(t, err)
+	// when
+	err = idx.Remove(u)
+	// then
+	require.NoError(t, err)
+
+	uris, err := idx.URIs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{contextURI, partOf, pricingTag}, uris)
+
+	related, err := idx.RelatedBy(partOf)
+	require.NoError(t, err)
+	assert.Empty(t, related)
+}
+
+func TestOpen_ReusesUpToDateIndex(t *testing.T) {
+	// given
+	root := newStore(t)
+	idx, err := index.Open(root)
+	require.NoError(t, err)
+
+	u, err := uri.Parse(conceptURI)
+	require.NoError(t, err)
+
+	// index content that is not on disk: only a rebuild would drop it
+	content, err := model.EncodeConcept(concept(conceptURI, pricingTag, "scio://tags/marker"))
+	require.NoError(t, err)
+	require.NoError(t, idx.Update(u, []byte(content)))
+	require.NoError(t, idx.Close())
+	// when
+	idx = open(t, root)
+	// then
+	tagged, err := idx.Tagged("scio://tags/marker")
+	require.NoError(t, err)
+	assert.Equal(t, []string{conceptURI}, tagged)
+}
+
+func TestEntries(t *testing.T) {
+	// given
+	idx := open(t, newStore(t))
+	// when
+	entries, err := idx.Entries(model.EntityTypeConcept, model.EntityTypeContext)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []index.Entry{
+		{URI: contextURI, Entity: model.EntityTypeContext, Context: "ecommerce", Slug: "ecommerce", Name: "E-commerce", Version: 1},
+		{
+			URI:     conceptURI,
+			Entity:  model.EntityTypeConcept,
+			Context: "ecommerce",
+			Domain:  "pricing",
+			Slug:    "discount",
+			Name:    "Discount",
+			Version: 1,
+			Tags:    []string{pricingTag},
+		},
+	}, entries)
+}
+
+func TestSync(t *testing.T) {
+	// given
+	root := newStore(t)
+	idx := open(t, root)
+
+	save(t, root, couponURI, func() (string, error) {
+		return model.EncodeConcept(concept(couponURI, pricingTag))
+	})
+	save(t, root, conceptURI, func() (string, error) {
+		return model.EncodeConcept(concept(conceptURI))
+	})
+
+	u, err := uri.Parse(conceptURI)
+	require.NoError(t, err)
+
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(storage.FileName(root, u), later, later))
+
+	tag, err := uri.Parse(pricingTag)
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(storage.FileName(root, tag)))
+	files, err := storage.ScanEntityFiles(root)
+	require.NoError(t, err)
+	// when
+	changed, err := idx.Sync(files)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{couponURI, conceptURI, pricingTag}, changed)
+
+	tagged, err := idx.Tagged(pricingTag)
+	require.NoError(t, err)
+	assert.Equal(t, []string{couponURI}, tagged)
+
+	uris, err := idx.URIs(model.EntityTypeTag)
+	require.NoError(t, err)
+	assert.Empty(t, uris)
+}
+
+func TestSync_Unchanged(t *testing.T) {
+	// given
+	root := newStore(t)
+	idx := open(t, root)
+	u, err := uri.Parse(conceptURI)
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveSnapshot(root, u, 1, []byte("history")))
+	files, err := storage.ScanEntityFiles(root)
+	require.NoError(t, err)
+	// when
+	changed, err := idx.Sync(files)
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+}
+
+func TestSync_StaleScan(t *testing.T) {
+	// given
+	root := newStore(t)
+	idx := open(t, root)
+	files, err := storage.ScanEntityFiles(root)
+	require.NoError(t, err)
+	// another server writes and indexes two files after the scan
+	u, err := uri.Parse(couponURI)
+	require.NoError(t, err)
+	content, err := model.EncodeConcept(concept(couponURI, pricingTag))
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveFile(root, u, []byte(content)))
+	require.NoError(t, idx.Update(u, []byte(content)))
+	u, err = uri.Parse(conceptURI)
+	require.NoError(t, err)
+	content, err = model.EncodeConcept(concept(conceptURI, pricingTag))
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveFile(root, u, []byte(content)))
+	require.NoError(t, idx.Update(u, []byte(content)))
+	// when
+	changed, err := idx.Sync(files)
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+
+	tagged, err := idx.Tagged(pricingTag)
+	require.NoError(t, err)
+	assert.Equal(t, []string{couponURI, conceptURI}, tagged)
+}
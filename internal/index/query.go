@@ -0,0 +1,164 @@
+package index
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const timeLayout = time.RFC3339
+
+// Edge is a relation held by Source pointing at Target.
+type Edge struct {
+	Source string `json:"source"`
+	Type   string `json:"type"`
+	Target string `json:"target"`
+}
+
+// Entry summarizes an indexed entity.
+type Entry struct {
+	URI     string
+	Entity  string
+	Context string
+	Domain  string
+	Slug    string
+	Name    string
+	Version int
+	Tags    []string
+}
+
+// Entries returns the parsed entities of the given types, or all of them
+// when no type is given, in URI order.
+func (i *Index) Entries(entityTypes ...string) ([]Entry, error) {
+	filter, args := inClause("entity", entityTypes)
+
+	rows, err := i.db.Query(`SELECT uri, entity, context, domain, slug, name, version FROM entities`+filter+` ORDER BY uri`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query index: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []Entry
+
+	for rows.Next() {
+		var e Entry
+		if err := rows.Scan(&e.URI, &e.Entity, &e.Context, &e.Domain, &e.Slug, &e.Name, &e.Version); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tags, err := i.tagsByEntity()
+	if err != nil {
+		return nil, err
+	}
+
+	for n := range entries {
+		entries[n].Tags = tags[entries[n].URI]
+	}
+
+	return entries, nil
+}
+
+func (i *Index) tagsByEntity() (map[string][]string, error) {
+	rows, err := i.db.Query(`SELECT uri, tag FROM tags ORDER BY rowid`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query index: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make(map[string][]string)
+
+	for rows.Next() {
+		var raw, tag string
+		if err := rows.Scan(&raw, &tag); err != nil {
+			return nil, err
+		}
+
+		tags[raw] = append(tags[raw], tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// URIs returns the URIs of every indexed entity file of the given types, or
+// of all of them when no type is given, in URI order.
+func (i *Index) URIs(entityTypes ...string) ([]string, error) {
+	filter, args := inClause("entity", entityTypes)
+	return i.strings(`SELECT uri FROM files`+filter+` ORDER BY uri`, args...)
+}
+
+// Tagged returns the URIs of the entities whose Tags include tagURI.
+func (i *Index) Tagged(tagURI string) ([]string, error) {
+	return i.strings(`SELECT DISTINCT uri FROM tags WHERE tag = ? ORDER BY uri`, tagURI)
+}
+
+// RelatedBy returns the URIs of the entities holding at least one relation
+// of type relationURI.
+func (i *Index) RelatedBy(relationURI string) ([]string, error) {
+	return i.strings(`SELECT DISTINCT source FROM relations WHERE type = ? ORDER BY source`, relationURI)
+}
+
+// Outgoing returns the relations held by source.
+func (i *Index) Outgoing(source string) ([]Edge, error) {
+	return i.edges(`SELECT source, type, target FROM relations WHERE source = ? ORDER BY rowid`, source)
+}
+
+// Incoming returns the relations of any entity pointing at target.
+func (i *Index) Incoming(target string) ([]Edge, error) {
+	return i.edges(`SELECT source, type, target FROM relations WHERE target = ? ORDER BY source, rowid`, target)
+}
+
+func (i *Index) strings(query string, args ...any) ([]string, error) {
+	rows, err := i.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query index: %w", err)
+	}
+
+	return scanStrings(rows)
+}
+
+func (i *Index) edges(query string, args ...any) ([]Edge, error) {
+	rows, err := i.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query index: %w", err)
+	}
+	defer rows.Close()
+
+	var edges []Edge
+
+	for rows.Next() {
+		var e Edge
+		if err := rows.Scan(&e.Source, &e.Type, &e.Target); err != nil {
+			return nil, err
+		}
+
+		edges = append(edges, e)
+	}
+
+	return edges, rows.Err()
+}
+
+// inClause returns a WHERE clause restricting column to values, or nothing
+// when values is empty, together with its arguments.
+func inClause(column string, values []string) (string, []any) {
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	args := make([]any, 0, len(values))
+	for _, v := range values {
+		args = append(args, v)
+	}
+
+	return ` WHERE ` + column + ` IN (` + placeholders(len(values)) + `)`, args
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
@@ -0,0 +1,75 @@
+package index
+
+// schemaVersion is stored as the SQLite user_version. An index file written
+// with a different version is rebuilt on open.
+const schemaVersion = 3
+
+var dropStatements = []string{
+	`DROP TABLE IF EXISTS files`,
+	`DROP TABLE IF EXISTS entities`,
+	`DROP TABLE IF EXISTS tags`,
+	`DROP TABLE IF EXISTS relations`,
+	// sources is no longer indexed, it is dropped from older index files
+	`DROP TABLE IF EXISTS sources`,
+	`DROP TABLE IF EXISTS documents`,
+}
+
+// files records every entity file seen on disk, including the ones that
+// could not be parsed, so that staleness can be detected by comparing
+// modification times and sizes.
+var createStatements = []string{
+	`CREATE TABLE files (
+		path     TEXT PRIMARY KEY,
+		uri      TEXT NOT NULL UNIQUE,
+		entity   TEXT NOT NULL,
+		mod_time INTEGER NOT NULL,
+		size     INTEGER NOT NULL
+	)`,
+	`CREATE TABLE entities (
+		uri         TEXT PRIMARY KEY,
+		entity      TEXT NOT NULL,
+		context     TEXT NOT NULL,
+		domain      TEXT NOT NULL,
+		slug        TEXT NOT NULL,
+		name        TEXT NOT NULL,
+		version     INTEGER NOT NULL,
+		last_update TEXT NOT NULL
+	)`,
+	`CREATE INDEX entities_entity ON entities (entity)`,
+	`CREATE TABLE tags (
+		uri TEXT NOT NULL,
+		tag TEXT NOT NULL
+	)`,
+	`CREATE INDEX tags_uri ON tags (uri)`,
+	`CREATE INDEX tags_tag ON tags (tag)`,
+	`CREATE TABLE relations (
+		source TEXT NOT NULL,
+		type   TEXT NOT NULL,
+		target TEXT NOT NULL
+	)`,
+	`CREATE INDEX relations_source ON relations (source)`,
+	`CREATE INDEX relations_type ON relations (type)`,
+	`CREATE INDEX relations_target ON relations (target)`,
+	// documents is the full-text index of contexts, domains and concepts;
+	// tags holds the slugs of the entity tags.
+	`CREATE VIRTUAL TABLE documents USING fts5 (
+		uri UNINDEXED,
+		name,
+		body,
+		tags,
+		tokenize = 'porter unicode61'
+	)`,
+}
+
+// entityTables lists the tables holding rows of a single entity, with the
+// column that identifies it.
+var entityTables = []struct {
+	name   string
+	column string
+}{
+	{"files", "uri"},
+	{"entities", "uri"},
+	{"tags", "uri"},
+	{"relations", "source"},
+	{"documents", "uri"},
+}
@@ -0,0 +1,113 @@
+package index
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Markers around the matched terms of a snippet.
+const (
+	HighlightStart = "**"
+	HighlightEnd   = "**"
+)
+
+const snippetTokens = 16
+
+// TextQuery is a full-text query. Every word of Query must appear in the
+// name, body or tag slugs of a hit. Context and Domain are slugs, Tag is a
+// tag URI; empty filters match everything.
+type TextQuery struct {
+	Query       string
+	EntityTypes []string
+	Context     string
+	Domain      string
+	Tag         string
+	Limit       int
+}
+
+// TextHit is an entity matching a full-text query. Snippet is an excerpt of
+// the best matching field with the matched terms highlighted. Lower ranks
+// are better matches.
+type TextHit struct {
+	URI     string
+	Entity  string
+	Name    string
+	Snippet string
+	Rank    float64
+}
+
+// SearchText runs a full-text query over contexts, domains and concepts,
+// ranked by BM25 with name matches weighing most, then tags, then body.
+func (i *Index) SearchText(q TextQuery) ([]TextHit, error) {
+	match := matchExpression(q.Query)
+	if match == "" {
+		return nil, nil
+	}
+
+	query := `SELECT d.uri, e.entity, e.name, snippet(documents, -1, ?, ?, '…', ?), bm25(documents, 0, 10.0, 1.0, 5.0) AS rank
+		FROM documents d JOIN entities e ON e.uri = d.uri
+		WHERE documents MATCH ?`
+	args := []any{HighlightStart, HighlightEnd, snippetTokens, match}
+
+	if len(q.EntityTypes) > 0 {
+		query += ` AND e.entity IN (` + placeholders(len(q.EntityTypes)) + `)`
+
+		for _, t := range q.EntityTypes {
+			args = append(args, t)
+		}
+	}
+
+	if q.Context != "" {
+		query += ` AND e.context = ?`
+		args = append(args, q.Context)
+	}
+
+	if q.Domain != "" {
+		query += ` AND e.domain = ?`
+		args = append(args, q.Domain)
+	}
+
+	if q.Tag != "" {
+		query += ` AND d.uri IN (SELECT uri FROM tags WHERE tag = ?)`
+		args = append(args, q.Tag)
+	}
+
+	query += ` ORDER BY rank, d.uri`
+
+	if q.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, q.Limit)
+	}
+
+	rows, err := i.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query index: %w", err)
+	}
+	defer rows.Close()
+
+	var hits []TextHit
+
+	for rows.Next() {
+		var h TextHit
+		if err := rows.Scan(&h.URI, &h.Entity, &h.Name, &h.Snippet, &h.Rank); err != nil {
+			return nil, err
+		}
+
+		hits = append(hits, h)
+	}
+
+	return hits, rows.Err()
+}
+
+// matchExpression turns free text into an FTS5 query requiring every word,
+// quoting each one so that punctuation is never read as query syntax.
+func matchExpression(text string) string {
+	words := strings.Fields(text)
+	terms := make([]string, 0, len(words))
+
+	for _, w := range words {
+		terms = append(terms, `"`+strings.ReplaceAll(w, `"`, `""`)+`"`)
+	}
+
+	return strings.Join(terms, " ")
+}
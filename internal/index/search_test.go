@@ -0,0 +1,108 @@
+package index_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/index"
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// withBody stores the concept at rawURI with the given name and body and
+// indexes it.
+func withBody(t *testing.T, idx *index.Index, root, rawURI, name, body string, tags ...string) {
+	t.Helper()
+
+	c := concept(rawURI, tags...)
+	c.Name = name
+	c.Body = body
+	save(t, root, rawURI, func() (string, error) { return model.EncodeConcept(c) })
+
+	u, err := uri.Parse(rawURI)
+	require.NoError(t, err)
+
+	content, err := model.EncodeConcept(c)
+	require.NoError(t, err)
+	require.NoError(t, idx.Update(u, []byte(content)))
+}
+
+func searchURIs(hits []index.TextHit) []string {
+	uris := make([]string, 0, len(hits))
+	for _, h := range hits {
+		uris = append(uris, h.URI)
+	}
+
+	return uris
+}
+
+func TestSearchText(t *testing.T) {
+	// given
+	root := newStore(t)
+	idx := open(t, root)
+	withBody(t, idx, root, conceptURI, "Discount", "Tiered discounts are applied at checkout.\n", pricingTag)
+	withBody(t, idx, root, couponURI, "Coupon", "A coupon grants a discount once.\n")
+	// when
+	hits, err := idx.SearchText(index.TextQuery{Query: "discounts"})
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{conceptURI, couponURI}, searchURIs(hits))
+	assert.Equal(t, "**Discount**", hits[0].Snippet)
+	assert.Equal(t, "A coupon grants a **discount** once.\n", hits[1].Snippet)
+	assert.Less(t, hits[0].Rank, hits[1].Rank)
+}
+
+func TestSearchText_Filters(t *testing.T) {
+	root := newStore(t)
+	idx := open(t, root)
+	withBody(t, idx, root, conceptURI, "Discount", "Checkout rules.\n", pricingTag)
+	withBody(t, idx, root, couponURI, "Coupon", "Checkout codes.\n")
+
+	tests := []struct {
+		name     string
+		query    index.TextQuery
+		expected []string
+	}{
+		{"all words required", index.TextQuery{Query: "checkout codes"}, []string{couponURI}},
+		{"tag slugs", index.TextQuery{Query: "pricing", EntityTypes: []string{model.EntityTypeConcept}}, []string{conceptURI}},
+		{"by tag", index.TextQuery{Query: "checkout", Tag: pricingTag}, []string{conceptURI}},
+		{"by entity type", index.TextQuery{Query: "checkout", EntityTypes: []string{model.EntityTypeDomain}}, nil},
+		{"by context", index.TextQuery{Query: "checkout", Context: "logistics"}, nil},
+		{"by domain", index.TextQuery{Query: "checkout", Context: "ecommerce", Domain: "pricing"}, []string{couponURI, conceptURI}},
+		{"limit", index.TextQuery{Query: "checkout", Limit: 1}, []string{couponURI}},
+		{"query syntax is literal", index.TextQuery{Query: `checkout" OR "x`}, nil},
+		{"blank query", index.TextQuery{Query: "  "}, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			hits, err := idx.SearchText(tc.query)
+			require.NoError(t, err)
+
+			if tc.expected == nil {
+				assert.Empty(t, hits)
+				return
+			}
+
+			assert.ElementsMatch(t, tc.expected, searchURIs(hits))
+		})
+	}
+}
+
+func TestSearchText_FollowsRemovals(t *testing.T) {
+	// given
+	root := newStore(t)
+	idx := open(t, root)
+	withBody(t, idx, root, couponURI, "Coupon", "Single use.\n")
+
+	u, err := uri.Parse(domainURI)
+	require.NoError(t, err)
+	// when
+	require.NoError(t, idx.Remove(u))
+	// then
+	hits, err := idx.SearchText(index.TextQuery{Query: "coupon"})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+}
@@ -1,15 +1,41 @@
 package model
 
+import (
+	"fmt"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/entity"
+	"gopkg.in/yaml.v3"
+)
+
 // RelationRef is a directed edge from a source entity to a target entity
 // via a named relation type.
 type RelationRef struct {
-	Type   string `yaml:"type"`
-	Target string `yaml:"target"`
+	Type   string `yaml:"type" json:"type"`
+	Target string `yaml:"target" json:"target"`
 }
 
 // Source is a reference to an external artifact (file, URL, etc.) that
 // supports or documents a concept.
 type Source struct {
-	Type string `yaml:"type"`
-	Href string `yaml:"href"`
+	Type string `yaml:"type" json:"type"`
+	Href string `yaml:"href" json:"href"`
+}
+
+// ParseVersion reads the version from the frontmatter of an entity file of
+// any type.
+func ParseVersion(content string) (int, error) {
+	entityContent, err := entity.ParseContent(content)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse entity file: %w", err)
+	}
+
+	var header struct {
+		Version int `yaml:"version"`
+	}
+
+	if err := yaml.Unmarshal([]byte(entityContent.Metadata), &header); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal entity metadata: %w", err)
+	}
+
+	return header.Version, nil
 }
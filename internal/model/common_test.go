@@ -0,0 +1,29 @@
+package model_test
+
+import (
+	"testing"
+
+	v1 "github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	// given
+	content := "---\nentity: concept\nschema: 1\nversion: 7\n---\nBody.\n"
+
+	// when
+	version, err := v1.ParseVersion(content)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 7, version)
+}
+
+func TestParseVersion_NotAnEntity(t *testing.T) {
+	// when
+	_, err := v1.ParseVersion("no frontmatter")
+
+	// then
+	assert.Error(t, err)
+}
@@ -7,3 +7,6 @@ const (
 	EntityTypeDomain   = "domain"
 	EntityTypeConcept  = "concept"
 )
+
+// SchemaVersion is the frontmatter schema version written by this build.
+const SchemaVersion = 1
@@ -9,16 +9,16 @@ import (
 )
 
 type Domain struct {
-	Entity     string        `yaml:"entity"`
-	Schema     int           `yaml:"schema"`
-	URI        string        `yaml:"uri"`
-	Name       string        `yaml:"name"`
-	Version    int           `yaml:"version"`
-	Created    time.Time     `yaml:"created"`
-	LastUpdate time.Time     `yaml:"last-update"`
-	Tags       []string      `yaml:"tags"`
-	Relations  []RelationRef `yaml:"relations"`
-	Body       string        `yaml:"-"`
+	Entity     string        `yaml:"entity" json:"entity"`
+	Schema     int           `yaml:"schema" json:"schema"`
+	URI        string        `yaml:"uri" json:"uri"`
+	Name       string        `yaml:"name" json:"name"`
+	Version    int           `yaml:"version" json:"version"`
+	Created    time.Time     `yaml:"created" json:"created"`
+	LastUpdate time.Time     `yaml:"last-update" json:"last_update"`
+	Tags       []string      `yaml:"tags" json:"tags"`
+	Relations  []RelationRef `yaml:"relations" json:"relations"`
+	Body       string        `yaml:"-" json:"body"`
 }
 
 func ParseDomain(content string) (*Domain, error) {
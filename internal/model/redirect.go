@@ -0,0 +1,60 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Reasons a URI was retired.
+const (
+	RedirectMoved  = "moved"
+	RedirectMerged = "merged"
+)
+
+// Redirect points a retired URI at the URI of the entity that replaced it.
+type Redirect struct {
+	From    string    `yaml:"from" json:"from"`
+	To      string    `yaml:"to" json:"to"`
+	Reason  string    `yaml:"reason" json:"reason"`
+	Created time.Time `yaml:"created" json:"created"`
+}
+
+type redirectsFile struct {
+	Schema    int        `yaml:"schema"`
+	Redirects []Redirect `yaml:"redirects"`
+}
+
+// ParseRedirects reads the redirects file. Empty content holds no redirects.
+func ParseRedirects(content string) ([]Redirect, error) {
+	var file redirectsFile
+	if err := yaml.Unmarshal([]byte(content), &file); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal redirects: %w", err)
+	}
+
+	if file.Schema != 0 && file.Schema != SchemaVersion {
+		return nil, fmt.Errorf("unsupported redirects schema %d", file.Schema)
+	}
+
+	if file.Redirects == nil {
+		file.Redirects = []Redirect{}
+	}
+
+	return file.Redirects, nil
+}
+
+func EncodeRedirects(redirects []Redirect) (string, error) {
+	file := redirectsFile{Schema: SchemaVersion, Redirects: redirects}
+
+	if file.Redirects == nil {
+		file.Redirects = []Redirect{}
+	}
+
+	content, err := yaml.Marshal(&file)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode redirects: %w", err)
+	}
+
+	return string(content), nil
+}
@@ -0,0 +1,48 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedirects_RoundTrip(t *testing.T) {
+	// given
+	redirects := []v1.Redirect{
+		{
+			From:    "scio://tags/price",
+			To:      "scio://tags/pricing",
+			Reason:  v1.RedirectMoved,
+			Created: time.Date(2026, 2, 15, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	// when
+	content, err := v1.EncodeRedirects(redirects)
+	require.NoError(t, err)
+	parsed, err := v1.ParseRedirects(content)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, redirects, parsed)
+}
+
+func TestParseRedirects_Empty(t *testing.T) {
+	// when
+	redirects, err := v1.ParseRedirects("")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []v1.Redirect{}, redirects)
+}
+
+func TestParseRedirects_UnsupportedSchema(t *testing.T) {
+	// when
+	_, err := v1.ParseRedirects("schema: 9\nredirects: []\n")
+
+	// then
+	assert.Error(t, err)
+}
@@ -9,18 +9,18 @@ import (
 )
 
 type RelationType struct {
-	Entity                string    `yaml:"entity"`
-	Schema                int       `yaml:"schema"`
-	URI                   string    `yaml:"uri"`
-	Version               int       `yaml:"version"`
-	Created               time.Time `yaml:"created"`
-	LastUpdate            time.Time `yaml:"last-update"`
-	InverseOf             string    `yaml:"inverse-of,omitempty"`
-	AllowedSourceEntities []string  `yaml:"allowed-source-entities"`
-	AllowedTargetEntities []string  `yaml:"allowed-target-entities"`
-	Transitive            bool      `yaml:"transitive"`
-	Symmetric             bool      `yaml:"symmetric"`
-	Body                  string    `yaml:"-"`
+	Entity                string    `yaml:"entity" json:"entity"`
+	Schema                int       `yaml:"schema" json:"schema"`
+	URI                   string    `yaml:"uri" json:"uri"`
+	Version               int       `yaml:"version" json:"version"`
+	Created               time.Time `yaml:"created" json:"created"`
+	LastUpdate            time.Time `yaml:"last-update" json:"last_update"`
+	InverseOf             string    `yaml:"inverse-of,omitempty" json:"inverse_of,omitempty"`
+	AllowedSourceEntities []string  `yaml:"allowed-source-entities" json:"allowed_source_entities"`
+	AllowedTargetEntities []string  `yaml:"allowed-target-entities" json:"allowed_target_entities"`
+	Transitive            bool      `yaml:"transitive" json:"transitive"`
+	Symmetric             bool      `yaml:"symmetric" json:"symmetric"`
+	Body                  string    `yaml:"-" json:"body"`
 }
 
 func ParseRelationType(content string) (*RelationType, error) {
@@ -9,16 +9,16 @@ import (
 )
 
 type Tag struct {
-	Entity          string    `yaml:"entity"`
-	Schema          int       `yaml:"schema"`
-	URI             string    `yaml:"uri"`
-	Version         int       `yaml:"version"`
-	Created         time.Time `yaml:"created"`
-	LastUpdate      time.Time `yaml:"last-update"`
-	AllowedEntities []string  `yaml:"allowed-entities"`
-	Broader         []string  `yaml:"broader"`
-	Narrower        []string  `yaml:"narrower"`
-	Body            string    `yaml:"-"`
+	Entity          string    `yaml:"entity" json:"entity"`
+	Schema          int       `yaml:"schema" json:"schema"`
+	URI             string    `yaml:"uri" json:"uri"`
+	Version         int       `yaml:"version" json:"version"`
+	Created         time.Time `yaml:"created" json:"created"`
+	LastUpdate      time.Time `yaml:"last-update" json:"last_update"`
+	AllowedEntities []string  `yaml:"allowed-entities" json:"allowed_entities"`
+	Broader         []string  `yaml:"broader" json:"broader"`
+	Narrower        []string  `yaml:"narrower" json:"narrower"`
+	Body            string    `yaml:"-" json:"body"`
 }
 
 func ParseTag(content string) (*Tag, error) {
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// Backlinks lists the relations pointing at an entity.
+type Backlinks struct {
+	URI    string          `json:"uri"`
+	Total  int             `json:"total"`
+	Groups []BacklinkGroup `json:"groups"`
+}
+
+// BacklinkGroup holds the sources of the relations of one type pointing at
+// an entity, for one source entity type.
+type BacklinkGroup struct {
+	RelationType string   `json:"relation_type"`
+	SourceEntity string   `json:"source_entity"`
+	Sources      []string `json:"sources"`
+}
+
+// Backlinks returns every relation pointing at rawURI, grouped by relation
+// type and source entity type. The entity itself need not exist, so that
+// dangling relations can be found too.
+func (s *Service) Backlinks(rawURI string) (*Backlinks, error) {
+	u, err := uri.Parse(rawURI)
+	if err != nil {
+		return nil, invalidURIError(rawURI, err)
+	}
+
+	relations, err := s.relationGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	incoming, err := relations.incoming(u.Raw)
+	if err != nil {
+		return nil, err
+	}
+
+	backlinks := &Backlinks{URI: u.Raw, Groups: []BacklinkGroup{}}
+	groups := make(map[[2]string]*BacklinkGroup)
+
+	for _, e := range incoming {
+		source, err := uri.Parse(e.Source)
+		if err != nil {
+			continue
+		}
+
+		key := [2]string{e.Type, source.Entity}
+		group, ok := groups[key]
+
+		if !ok {
+			group = &BacklinkGroup{RelationType: e.Type, SourceEntity: source.Entity}
+			groups[key] = group
+		}
+
+		if !slices.Contains(group.Sources, e.Source) {
+			group.Sources = append(group.Sources, e.Source)
+			backlinks.Total++
+		}
+	}
+
+	for _, group := range groups {
+		slices.Sort(group.Sources)
+		backlinks.Groups = append(backlinks.Groups, *group)
+	}
+
+	slices.SortFunc(backlinks.Groups, func(a, b BacklinkGroup) int {
+		return cmp.Or(cmp.Compare(a.RelationType, b.RelationType), cmp.Compare(a.SourceEntity, b.SourceEntity))
+	})
+
+	return backlinks, nil
+}
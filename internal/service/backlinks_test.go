@@ -0,0 +1,74 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+func TestBacklinks(t *testing.T) {
+	services := map[string]func(t *testing.T) *service.Service{
+		"scan": newService,
+		"index": func(t *testing.T) *service.Service {
+			svc, _ := newIndexedService(t)
+			return svc
+		},
+	}
+
+	for name, newSvc := range services {
+		t.Run(name, func(t *testing.T) {
+			// given
+			svc := newSvc(t)
+			populateGraph(t, svc)
+
+			c, err := svc.GetContext(contextURI)
+			require.NoError(t, err)
+			c.Relations = []model.RelationRef{{Type: dependsRelation, Target: conceptURI}}
+			_, err = svc.UpdateContext(c)
+			require.NoError(t, err)
+
+			_, err = svc.CreateConcept(&model.Concept{
+				URI:       voucherURI + "-v2",
+				Relations: []model.RelationRef{{Type: partOfRelation, Target: conceptURI}},
+			})
+			require.NoError(t, err)
+			// when
+			backlinks, err := svc.Backlinks(conceptURI)
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, &service.Backlinks{
+				URI:   conceptURI,
+				Total: 3,
+				Groups: []service.BacklinkGroup{
+					{RelationType: dependsRelation, SourceEntity: model.EntityTypeContext, Sources: []string{contextURI}},
+					{RelationType: partOfRelation, SourceEntity: model.EntityTypeConcept, Sources: []string{campaignURI, voucherURI + "-v2"}},
+				},
+			}, backlinks)
+		})
+	}
+}
+
+func TestBacklinks_None(t *testing.T) {
+	// given
+	svc := newService(t)
+	// when
+	backlinks, err := svc.Backlinks(conceptURI)
+	// then
+	require.NoError(t, err)
+	assert.Zero(t, backlinks.Total)
+	assert.Empty(t, backlinks.Groups)
+}
+
+func TestBacklinks_InvalidURI(t *testing.T) {
+	// given
+	svc := newService(t)
+	// when
+	_, err := svc.Backlinks("scio://nowhere")
+	// then
+	requireAppError(t, err, outputs.ErrInvalidURIFormat)
+}
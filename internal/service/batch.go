@@ -0,0 +1,325 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// Batch operation kinds.
+const (
+	BatchCreate = "create"
+	BatchUpdate = "update"
+	BatchDelete = "delete"
+)
+
+var batchOps = []string{BatchCreate, BatchUpdate, BatchDelete}
+
+// BatchOperation is a single write inside a batch. URI identifies the entity
+// for every operation. Creates and updates also carry the entity to write as
+// one of the model pointer types; deletes use Confirm for non-empty contexts
+// and domains.
+type BatchOperation struct {
+	Op      string
+	URI     string
+	Entity  any
+	Confirm bool
+}
+
+// BatchResult describes the outcome of one operation of a committed batch.
+// Version is the version stored after the whole batch was applied, and is
+// omitted for deletes.
+type BatchResult struct {
+	Index   int    `json:"index"`
+	Op      string `json:"op"`
+	URI     string `json:"uri"`
+	Entity  string `json:"entity"`
+	Version int    `json:"version,omitempty"`
+}
+
+// BatchFailure reports why one operation of a rejected batch failed.
+type BatchFailure struct {
+	Index int               `json:"index"`
+	Op    string            `json:"op"`
+	URI   string            `json:"uri"`
+	Error *outputs.AppError `json:"error"`
+}
+
+// batchState records what each operation of a batch wrote or removed, so that
+// the checks deferred by checkEntity and checkUnused can run once the whole
+// batch is applied and be attributed to the operation that caused them.
+type batchState struct {
+	current int
+	written map[string]int
+	deleted map[string]int
+	order   []*uri.URI
+}
+
+func newBatchState() *batchState {
+	return &batchState{
+		written: make(map[string]int),
+		deleted: make(map[string]int),
+	}
+}
+
+func (b *batchState) wrote(u *uri.URI) {
+	if _, ok := b.written[u.Raw]; !ok {
+		b.order = append(b.order, u)
+	}
+
+	b.written[u.Raw] = b.current
+	delete(b.deleted, u.Raw)
+}
+
+func (b *batchState) removed(u *uri.URI) {
+	if _, ok := b.deleted[u.Raw]; !ok {
+		b.order = append(b.order, u)
+	}
+
+	b.deleted[u.Raw] = b.current
+}
+
+// Batch applies ops in order as a single unit. Every operation sees the
+// entities written by the operations before it, and the referential checks
+// run once against the final state, so entities in the batch may reference
+// each other in any order. Either every file is written or none is; when any
+// operation fails, the returned ErrBatchValidationFailed error lists every
+// failure by operation index.
+func (s *Service) Batch(ops []BatchOperation) ([]BatchResult, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	overlay := newOverlayStore(s.files)
+	tx := s.transaction(overlay)
+	tx.batch = newBatchState()
+
+	var failures []BatchFailure
+
+	fail := func(index int, err error) error {
+		var appErr *outputs.AppError
+		if !errors.As(err, &appErr) {
+			return err
+		}
+
+		failures = append(failures, BatchFailure{
+			Index: index,
+			Op:    ops[index].Op,
+			URI:   ops[index].URI,
+			Error: appErr,
+		})
+
+		return nil
+	}
+
+	for i, op := range ops {
+		tx.batch.current = i
+
+		if err := tx.applyBatchOperation(op); err != nil {
+			if err := fail(i, err); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.finishBatch(fail); err != nil {
+		return nil, err
+	}
+
+	if len(failures) > 0 {
+		return nil, batchValidationFailedError(len(ops), failures)
+	}
+
+	results, err := tx.batchResults(ops)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.commit(overlay); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (s *Service) applyBatchOperation(op BatchOperation) error {
+	switch op.Op {
+	case BatchCreate:
+		return s.createEntity(op)
+	case BatchUpdate:
+		return s.updateEntity(op)
+	case BatchDelete:
+		return s.deleteEntity(op)
+	default:
+		return validationError(op.URI, fmt.Sprintf("unknown batch operation %q", op.Op), map[string]any{
+			"field":   "op",
+			"value":   op.Op,
+			"allowed": batchOps,
+		})
+	}
+}
+
+func (s *Service) createEntity(op BatchOperation) error {
+	var err error
+
+	switch e := op.Entity.(type) {
+	case *model.Tag:
+		_, err = s.CreateTag(e)
+	case *model.RelationType:
+		_, err = s.CreateRelationType(e)
+	case *model.Context:
+		_, err = s.CreateContext(e)
+	case *model.Domain:
+		_, err = s.CreateDomain(e)
+	case *model.Concept:
+		_, err = s.CreateConcept(e)
+	default:
+		err = unsupportedBatchEntity(op)
+	}
+
+	return err
+}
+
+func (s *Service) updateEntity(op BatchOperation) error {
+	var err error
+
+	switch e := op.Entity.(type) {
+	case *model.Tag:
+		_, err = s.UpdateTag(e)
+	case *model.RelationType:
+		_, err = s.UpdateRelationType(e)
+	case *model.Context:
+		_, err = s.UpdateContext(e)
+	case *model.Domain:
+		_, err = s.UpdateDomain(e)
+	case *model.Concept:
+		_, err = s.UpdateConcept(e)
+	default:
+		err = unsupportedBatchEntity(op)
+	}
+
+	return err
+}
+
+func (s *Service) deleteEntity(op BatchOperation) error {
+	u, err := uri.Parse(op.URI)
+	if err != nil {
+		return invalidURIError(op.URI, err)
+	}
+
+	switch u.Entity {
+	case model.EntityTypeTag:
+		return s.DeleteTag(op.URI)
+	case model.EntityTypeRelation:
+		return s.DeleteRelationType(op.URI)
+	case model.EntityTypeContext:
+		_, err = s.DeleteContext(op.URI, op.Confirm)
+	case model.EntityTypeDomain:
+		_, err = s.DeleteDomain(op.URI, op.Confirm)
+	default:
+		err = s.DeleteConcept(op.URI)
+	}
+
+	return err
+}
+
+func unsupportedBatchEntity(op BatchOperation) error {
+	if _, err := uri.Parse(op.URI); err != nil {
+		return invalidURIError(op.URI, err)
+	}
+
+	return validationError(op.URI, "the operation does not carry an entity to write", map[string]any{
+		"field": "entity",
+	})
+}
+
+// finishBatch runs the checks deferred while the operations were applied.
+// Hierarchy and inverse links to entities created later in the batch are
+// completed first, then every entity still present is checked against the
+// final state and every deleted tag or relation type is checked for use.
+func (s *Service) finishBatch(fail func(index int, err error) error) error {
+	now := s.clock()
+
+	for _, u := range s.batch.order {
+		if _, ok := s.batch.written[u.Raw]; !ok || !s.exists(u) {
+			continue
+		}
+
+		var err error
+
+		switch u.Entity {
+		case model.EntityTypeTag:
+			var t *model.Tag
+			if t, err = s.loadTag(u); err == nil {
+				err = s.syncTagLinks(t, nil, now)
+			}
+		case model.EntityTypeRelation:
+			var r *model.RelationType
+			if r, err = s.loadRelationType(u); err == nil {
+				err = s.syncInverseLink(r, nil, now)
+			}
+		}
+
+		if err != nil {
+			if err := fail(s.batch.written[u.Raw], err); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, u := range s.batch.order {
+		if index, ok := s.batch.written[u.Raw]; ok && s.exists(u) {
+			entity, err := s.loadEntity(u)
+			if err == nil {
+				err = s.runChecks(u, entity)
+			}
+
+			if err != nil {
+				if err := fail(index, err); err != nil {
+					return err
+				}
+			}
+		}
+
+		if index, ok := s.batch.deleted[u.Raw]; ok && !s.exists(u) {
+			if err := s.runUnusedCheck(u); err != nil {
+				if err := fail(index, err); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) batchResults(ops []BatchOperation) ([]BatchResult, error) {
+	results := make([]BatchResult, 0, len(ops))
+
+	for i, op := range ops {
+		u, err := uri.Parse(op.URI)
+		if err != nil {
+			return nil, invalidURIError(op.URI, err)
+		}
+
+		result := BatchResult{Index: i, Op: op.Op, URI: u.Raw, Entity: u.Entity}
+
+		if op.Op != BatchDelete && s.exists(u) {
+			entity, err := s.loadEntity(u)
+			if err != nil {
+				return nil, err
+			}
+
+			result.Version = entityVersion(entity)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
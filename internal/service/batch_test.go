@@ -0,0 +1,199 @@
+package service_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+const otherConceptURI = domainURI + "/concepts/coupon"
+
+func batchFailures(t *testing.T, err error) []service.BatchFailure {
+	t.Helper()
+
+	appErr := requireAppError(t, err, outputs.ErrBatchValidationFailed)
+	failures, ok := appErr.Details["failures"].([]service.BatchFailure)
+	require.True(t, ok)
+	return failures
+}
+
+func TestBatch_ForwardReferences(t *testing.T) {
+	// given
+	svc := newService(t)
+	ops := []service.BatchOperation{
+		{Op: service.BatchCreate, URI: conceptURI, Entity: &model.Concept{
+			URI:       conceptURI,
+			Name:      "Discount",
+			Tags:      []string{discountTag},
+			Relations: []model.RelationRef{{Type: relatedRelation, Target: otherConceptURI}},
+		}},
+		{Op: service.BatchCreate, URI: otherConceptURI, Entity: &model.Concept{URI: otherConceptURI, Name: "Coupon"}},
+		{Op: service.BatchCreate, URI: domainURI, Entity: &model.Domain{URI: domainURI, Name: "Pricing"}},
+		{Op: service.BatchCreate, URI: contextURI, Entity: &model.Context{URI: contextURI, Name: "E-commerce"}},
+		{Op: service.BatchCreate, URI: discountTag, Entity: &model.Tag{URI: discountTag, Broader: []string{pricingTag}}},
+		{Op: service.BatchCreate, URI: pricingTag, Entity: &model.Tag{URI: pricingTag}},
+		{Op: service.BatchCreate, URI: relatedRelation, Entity: &model.RelationType{URI: relatedRelation, Symmetric: true}},
+	}
+	// when
+	results, err := svc.Batch(ops)
+	// then
+	require.NoError(t, err)
+	require.Len(t, results, len(ops))
+	assert.Equal(t, service.BatchResult{Index: 0, Op: "create", URI: conceptURI, Entity: "concept", Version: 1}, results[0])
+
+	concept, err := svc.GetConcept(conceptURI)
+	require.NoError(t, err)
+	assert.Equal(t, []string{discountTag}, concept.Tags)
+
+	pricing := getTag(t, svc, pricingTag)
+	assert.Equal(t, []string{discountTag}, pricing.Narrower)
+	assert.Equal(t, pricing.Version, results[5].Version)
+}
+
+func TestBatch_AllOrNothing(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	ops := []service.BatchOperation{
+		{Op: service.BatchCreate, URI: conceptURI, Entity: &model.Concept{URI: conceptURI, Name: "Discount"}},
+		{Op: service.BatchCreate, URI: otherConceptURI, Entity: &model.Concept{URI: otherConceptURI, Tags: []string{pricingTag}}},
+	}
+	// when
+	_, err := svc.Batch(ops)
+	// then
+	failures := batchFailures(t, err)
+	require.Len(t, failures, 1)
+	assert.Equal(t, 1, failures[0].Index)
+	assert.Equal(t, otherConceptURI, failures[0].URI)
+	assert.Equal(t, outputs.ErrTagNotFound, failures[0].Error.ErrorCode)
+
+	_, err = svc.GetConcept(conceptURI)
+	requireAppError(t, err, outputs.ErrNotFound)
+}
+
+func TestBatch_ReportsEveryFailure(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	createConcept(t, svc)
+	ops := []service.BatchOperation{
+		{Op: service.BatchCreate, URI: conceptURI, Entity: &model.Concept{URI: conceptURI}},
+		{Op: service.BatchUpdate, URI: conceptURI, Entity: &model.Concept{URI: conceptURI, Version: 7}},
+		{Op: service.BatchDelete, URI: "not-a-uri"},
+		{Op: "upsert", URI: otherConceptURI},
+	}
+	// when
+	_, err := svc.Batch(ops)
+	// then
+	failures := batchFailures(t, err)
+	require.Len(t, failures, 4)
+
+	codes := make([]string, 0, len(failures))
+	for i, f := range failures {
+		assert.Equal(t, i, f.Index)
+		codes = append(codes, f.Error.ErrorCode)
+	}
+
+	assert.Equal(t, []string{
+		outputs.ErrAlreadyExists,
+		outputs.ErrVersionConflict,
+		outputs.ErrInvalidURIFormat,
+		outputs.ErrValidationFailed,
+	}, codes)
+}
+
+func TestBatch_UpdateAndDelete(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	createConcept(t, svc)
+	createTag(t, svc, &model.Tag{URI: pricingTag})
+	_, err := svc.CreateConcept(&model.Concept{URI: otherConceptURI, Tags: []string{pricingTag}})
+	require.NoError(t, err)
+	ops := []service.BatchOperation{
+		{Op: service.BatchUpdate, URI: otherConceptURI, Entity: &model.Concept{URI: otherConceptURI, Name: "Coupon", Version: 1}},
+		{Op: service.BatchDelete, URI: pricingTag},
+		{Op: service.BatchDelete, URI: conceptURI},
+	}
+	// when
+	results, err := svc.Batch(ops)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 2, results[0].Version)
+	assert.Zero(t, results[2].Version)
+
+	_, err = svc.GetConcept(conceptURI)
+	requireAppError(t, err, outputs.ErrNotFound)
+	_, err = svc.GetTag(pricingTag)
+	requireAppError(t, err, outputs.ErrNotFound)
+}
+
+func TestBatch_DeleteTagStillUsed(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	createTag(t, svc, &model.Tag{URI: pricingTag})
+	_, err := svc.CreateConcept(&model.Concept{URI: conceptURI, Tags: []string{pricingTag}})
+	require.NoError(t, err)
+	// when
+	_, err = svc.Batch([]service.BatchOperation{{Op: service.BatchDelete, URI: pricingTag}})
+	// then
+	failures := batchFailures(t, err)
+	require.Len(t, failures, 1)
+	assert.Equal(t, outputs.ErrTagInUse, failures[0].Error.ErrorCode)
+	getTag(t, svc, pricingTag)
+}
+
+func TestBatch_DeleteContextRequiresConfirm(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	// when
+	_, err := svc.Batch([]service.BatchOperation{{Op: service.BatchDelete, URI: contextURI}})
+	// then
+	failures := batchFailures(t, err)
+	require.Len(t, failures, 1)
+	assert.Equal(t, outputs.ErrConfirmationRequired, failures[0].Error.ErrorCode)
+}
+
+func TestBatch_DeleteContextThenRecreate(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	createConcept(t, svc)
+	ops := []service.BatchOperation{
+		{Op: service.BatchDelete, URI: contextURI, Confirm: true},
+		{Op: service.BatchCreate, URI: contextURI, Entity: &model.Context{URI: contextURI, Name: "Shop"}},
+	}
+	// when
+	_, err := svc.Batch(ops)
+	// then
+	require.NoError(t, err)
+
+	c, err := svc.GetContext(contextURI)
+	require.NoError(t, err)
+	assert.Equal(t, "Shop", c.Name)
+	_, err = svc.GetDomain(domainURI)
+	requireAppError(t, err, outputs.ErrNotFound)
+}
+
+func TestBatch_RollsBackWhenWriteFails(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	blocked := filepath.Join(svc.RootDir(), "contexts", "ecommerce", "domains", "pricing", "coupon.md")
+	require.NoError(t, os.MkdirAll(filepath.Join(blocked, "occupied"), 0o755))
+	ops := []service.BatchOperation{
+		{Op: service.BatchCreate, URI: conceptURI, Entity: &model.Concept{URI: conceptURI}},
+		{Op: service.BatchCreate, URI: otherConceptURI, Entity: &model.Concept{URI: otherConceptURI}},
+	}
+	// when
+	_, err := svc.Batch(ops)
+	// then
+	require.Error(t, err)
+
+	_, err = svc.GetConcept(conceptURI)
+	requireAppError(t, err, outputs.ErrNotFound)
+	_, err = svc.History(conceptURI)
+	requireAppError(t, err, outputs.ErrNotFound)
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// GetConcept reads the concept at rawURI, following the redirect left
+// behind if it was moved or merged away.
+func (s *Service) GetConcept(rawURI string) (*model.Concept, error) {
+	u, err := parseURI(rawURI, model.EntityTypeConcept)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.loadConcept(s.followRedirect(u))
+}
+
+// CreateConcept stores a new concept at version 1. Entity, Schema, Version
+// and the timestamps are managed by the service and ignored on input.
+func (s *Service) CreateConcept(c *model.Concept) (*model.Concept, error) {
+	u, err := parseURI(c.URI, model.EntityTypeConcept)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if s.exists(u) {
+		return nil, alreadyExistsError(u.Raw)
+	}
+
+	now := s.clock()
+	created := *c
+	created.Entity = model.EntityTypeConcept
+	created.Schema = model.SchemaVersion
+	created.Version = 1
+	created.Created = now
+	created.LastUpdate = now
+
+	s.validator.Resolve(&created)
+
+	if err := s.checkEntity(u, &created); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveConcept(u, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// UpdateConcept replaces the stored concept, provided c.Version still matches
+// the version on disk. The stored version is incremented on success.
+func (s *Service) UpdateConcept(c *model.Concept) (*model.Concept, error) {
+	u, err := parseURI(c.URI, model.EntityTypeConcept)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	current, err := s.loadConcept(u)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.Version != c.Version {
+		return nil, versionConflictError(u.Raw, c.Version, current.Version)
+	}
+
+	updated := *c
+	updated.Entity = model.EntityTypeConcept
+	updated.Schema = model.SchemaVersion
+	updated.Version = current.Version + 1
+	updated.Created = current.Created
+	updated.LastUpdate = s.clock()
+
+	s.validator.Resolve(&updated)
+
+	if err := s.checkEntity(u, &updated); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveConcept(u, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (s *Service) DeleteConcept(rawURI string) error {
+	u, err := parseURI(rawURI, model.EntityTypeConcept)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return s.deleteFile(u)
+}
+
+func (s *Service) loadConcept(u *uri.URI) (*model.Concept, error) {
+	content, err := s.readFile(u)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := model.ParseConcept(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", u, err)
+	}
+
+	if err := checkSchema(u.Raw, c.Schema); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (s *Service) saveConcept(u *uri.URI, c *model.Concept) error {
+	content, err := model.EncodeConcept(c)
+	if err != nil {
+		return err
+	}
+
+	return s.writeFile(u, content)
+}
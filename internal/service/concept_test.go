@@ -0,0 +1,213 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+const conceptURI = domainURI + "/concepts/discount"
+
+func createConcept(t *testing.T, svc *service.Service) *model.Concept {
+	t.Helper()
+
+	c, err := svc.CreateConcept(&model.Concept{
+		URI:  conceptURI,
+		Name: "Discount",
+		Body: "Tiered discounts.\n",
+	})
+	require.NoError(t, err)
+	return c
+}
+
+func TestCreateConcept(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	// when
+	c := createConcept(t, svc)
+	// then
+	assert.Equal(t, model.EntityTypeConcept, c.Entity)
+	assert.Equal(t, model.SchemaVersion, c.Schema)
+	assert.Equal(t, 1, c.Version)
+	assert.False(t, c.Created.IsZero())
+	assert.Equal(t, c.Created, c.LastUpdate)
+
+	stored, err := svc.GetConcept(conceptURI)
+	require.NoError(t, err)
+	assert.Equal(t, "Discount", stored.Name)
+	assert.Equal(t, "Tiered discounts.\n", stored.Body)
+	assert.Equal(t, 1, stored.Version)
+}
+
+func TestCreateConcept_AlreadyExists(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	createConcept(t, svc)
+	// when
+	_, err := svc.CreateConcept(&model.Concept{URI: conceptURI, Name: "Other"})
+	// then
+	requireAppError(t, err, outputs.ErrAlreadyExists)
+}
+
+func TestCreateConcept_InvalidURI(t *testing.T) {
+	tests := []struct {
+		name string
+		uri  string
+		code string
+	}{
+		{"malformed", "scio://nowhere", outputs.ErrInvalidURIFormat},
+		{"not a concept", "scio://contexts/ecommerce", outputs.ErrTypeMismatch},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := newService(t)
+
+			_, err := svc.CreateConcept(&model.Concept{URI: tc.uri, Name: "X"})
+
+			requireAppError(t, err, tc.code)
+		})
+	}
+}
+
+func TestCreateConcept_ParentNotFound(t *testing.T) {
+	// given
+	svc := newService(t)
+	// when
+	_, err := svc.CreateConcept(&model.Concept{URI: conceptURI, Name: "Discount"})
+	// then
+	appErr := requireAppError(t, err, outputs.ErrParentNotFound)
+	assert.Equal(t, domainURI, appErr.Details["parent"])
+}
+
+func TestGetConcept_NotFound(t *testing.T) {
+	// given
+	svc := newService(t)
+	// when
+	_, err := svc.GetConcept(conceptURI)
+	// then
+	requireAppError(t, err, outputs.ErrNotFound)
+}
+
+func TestUpdateConcept(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	created := createConcept(t, svc)
+	// when
+	updated, err := svc.UpdateConcept(&model.Concept{
+		URI:     conceptURI,
+		Name:    "Discount Calculation",
+		Version: created.Version,
+	})
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Version)
+	assert.Equal(t, created.Created, updated.Created)
+
+	stored, err := svc.GetConcept(conceptURI)
+	require.NoError(t, err)
+	assert.Equal(t, "Discount Calculation", stored.Name)
+	assert.Equal(t, 2, stored.Version)
+}
+
+func TestUpdateConcept_VersionConflict(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	created := createConcept(t, svc)
+	_, err := svc.UpdateConcept(&model.Concept{URI: conceptURI, Name: "First", Version: created.Version})
+	require.NoError(t, err)
+	// when — a second writer still holds version 1
+	_, err = svc.UpdateConcept(&model.Concept{URI: conceptURI, Name: "Second", Version: created.Version})
+	// then
+	appErr := requireAppError(t, err, outputs.ErrVersionConflict)
+	assert.Equal(t, 2, appErr.Details["current_version"])
+	assert.Equal(t, 1, appErr.Details["expected_version"])
+	assert.NotEmpty(t, appErr.SuggestedAction)
+	assert.True(t, appErr.Recoverable)
+
+	stored, err := svc.GetConcept(conceptURI)
+	require.NoError(t, err)
+	assert.Equal(t, "First", stored.Name)
+}
+
+func TestUpdateConcept_NotFound(t *testing.T) {
+	// given
+	svc := newService(t)
+	// when
+	_, err := svc.UpdateConcept(&model.Concept{URI: conceptURI, Name: "X", Version: 1})
+	// then
+	requireAppError(t, err, outputs.ErrNotFound)
+}
+
+func TestDeleteConcept(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	createConcept(t, svc)
+	// when
+	err := svc.DeleteConcept(conceptURI)
+	// then
+	require.NoError(t, err)
+	_, err = svc.GetConcept(conceptURI)
+	requireAppError(t, err, outputs.ErrNotFound)
+}
+
+func TestDeleteConcept_NotFound(t *testing.T) {
+	// given
+	svc := newService(t)
+	// when
+	err := svc.DeleteConcept(conceptURI)
+	// then
+	requireAppError(t, err, outputs.ErrNotFound)
+}
+
+func TestCreateConcept_ReportsEveryViolation(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	// when
+	_, err := svc.CreateConcept(&model.Concept{
+		URI:       conceptURI,
+		Name:      "Discount",
+		Tags:      []string{"scio://tags/missing"},
+		Relations: []model.RelationRef{{Type: "scio://relations/missing", Target: domainURI + "/concepts/missing"}},
+		Sources:   []model.Source{{Type: "file"}},
+	})
+	// then
+	appErr := requireAppError(t, err, outputs.ErrValidationFailed)
+	assert.Len(t, appErr.Details["violations"], 4)
+	_, err = svc.GetConcept(conceptURI)
+	requireAppError(t, err, outputs.ErrNotFound)
+}
+
+func TestCreateConcept_ResolvesShortSlugs(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	createTag(t, svc, &model.Tag{URI: pricingTag})
+	createTag(t, svc, &model.Tag{URI: contextURI + "/tags/pricing"})
+	createTag(t, svc, &model.Tag{URI: discountTag})
+	// when
+	c, err := svc.CreateConcept(&model.Concept{URI: conceptURI, Name: "Discount", Tags: []string{"pricing", "discount"}})
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{contextURI + "/tags/pricing", discountTag}, c.Tags)
+}
+
+func TestCreateConcept_ScopeViolation(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	_, err := svc.CreateContext(&model.Context{URI: "scio://contexts/billing", Name: "Billing"})
+	require.NoError(t, err)
+	createTag(t, svc, &model.Tag{URI: "scio://contexts/billing/tags/invoice"})
+	// when
+	_, err = svc.CreateConcept(&model.Concept{
+		URI:  conceptURI,
+		Name: "Discount",
+		Tags: []string{"scio://contexts/billing/tags/invoice"},
+	})
+	// then
+	requireAppError(t, err, outputs.ErrScopeViolation)
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// GetContext reads the context at rawURI, following the redirect left
+// behind if it was moved.
+func (s *Service) GetContext(rawURI string) (*model.Context, error) {
+	u, err := parseURI(rawURI, model.EntityTypeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.loadContext(s.followRedirect(u))
+}
+
+// CreateContext stores a new context at version 1. Entity, Schema, Version
+// and the timestamps are managed by the service and ignored on input.
+func (s *Service) CreateContext(c *model.Context) (*model.Context, error) {
+	u, err := parseURI(c.URI, model.EntityTypeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if s.exists(u) {
+		return nil, alreadyExistsError(u.Raw)
+	}
+
+	now := s.clock()
+	created := *c
+	created.Entity = model.EntityTypeContext
+	created.Schema = model.SchemaVersion
+	created.Version = 1
+	created.Created = now
+	created.LastUpdate = now
+
+	s.validator.Resolve(&created)
+
+	if err := s.checkEntity(u, &created); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveContext(u, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// UpdateContext replaces the stored context, provided c.Version still matches
+// the version on disk. The URI cannot change.
+func (s *Service) UpdateContext(c *model.Context) (*model.Context, error) {
+	u, err := parseURI(c.URI, model.EntityTypeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	current, err := s.loadContext(u)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.Version != c.Version {
+		return nil, versionConflictError(u.Raw, c.Version, current.Version)
+	}
+
+	updated := *c
+	updated.Entity = model.EntityTypeContext
+	updated.Schema = model.SchemaVersion
+	updated.Version = current.Version + 1
+	updated.Created = current.Created
+	updated.LastUpdate = s.clock()
+
+	s.validator.Resolve(&updated)
+
+	if err := s.checkEntity(u, &updated); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveContext(u, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteContext removes a context together with everything stored inside
+// it. If the context is not empty, confirm must be true.
+func (s *Service) DeleteContext(rawURI string, confirm bool) ([]string, error) {
+	u, err := parseURI(rawURI, model.EntityTypeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return s.deleteContainer(u, confirm)
+}
+
+func (s *Service) loadContext(u *uri.URI) (*model.Context, error) {
+	content, err := s.readFile(u)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := model.ParseContext(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", u, err)
+	}
+
+	if err := checkSchema(u.Raw, c.Schema); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (s *Service) saveContext(u *uri.URI, c *model.Context) error {
+	content, err := model.EncodeContext(c)
+	if err != nil {
+		return err
+	}
+
+	return s.writeFile(u, content)
+}
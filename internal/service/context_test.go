@@ -0,0 +1,108 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+)
+
+func TestCreateContext(t *testing.T) {
+	// given
+	svc := newService(t)
+	// when
+	c, err := svc.CreateContext(&model.Context{URI: contextURI, Name: "E-commerce"})
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, model.EntityTypeContext, c.Entity)
+	assert.Equal(t, 1, c.Version)
+
+	stored, err := svc.GetContext(contextURI)
+	require.NoError(t, err)
+	assert.Equal(t, "E-commerce", stored.Name)
+}
+
+func TestCreateContext_AlreadyExists(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	// when
+	_, err := svc.CreateContext(&model.Context{URI: contextURI, Name: "Again"})
+	// then
+	requireAppError(t, err, outputs.ErrAlreadyExists)
+}
+
+func TestUpdateContext(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	// when
+	updated, err := svc.UpdateContext(&model.Context{URI: contextURI, Name: "Shop", Version: 1})
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Version)
+
+	stored, err := svc.GetContext(contextURI)
+	require.NoError(t, err)
+	assert.Equal(t, "Shop", stored.Name)
+}
+
+func TestUpdateContext_VersionConflict(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	// when
+	_, err := svc.UpdateContext(&model.Context{URI: contextURI, Name: "Shop", Version: 3})
+	// then
+	requireAppError(t, err, outputs.ErrVersionConflict)
+}
+
+func TestDeleteContext_Empty(t *testing.T) {
+	// given
+	svc := newService(t)
+	_, err := svc.CreateContext(&model.Context{URI: contextURI, Name: "E-commerce"})
+	require.NoError(t, err)
+	// when
+	contained, err := svc.DeleteContext(contextURI, false)
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, contained)
+	_, err = svc.GetContext(contextURI)
+	requireAppError(t, err, outputs.ErrNotFound)
+}
+
+func TestDeleteContext_RequiresConfirmation(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	createConcept(t, svc)
+	// when
+	_, err := svc.DeleteContext(contextURI, false)
+	// then
+	appErr := requireAppError(t, err, outputs.ErrConfirmationRequired)
+	assert.Equal(t, []string{domainURI, conceptURI}, appErr.Details["contained"])
+
+	_, err = svc.GetConcept(conceptURI)
+	assert.NoError(t, err)
+}
+
+func TestDeleteContext_Confirmed(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	createConcept(t, svc)
+	// when
+	contained, err := svc.DeleteContext(contextURI, true)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{domainURI, conceptURI}, contained)
+	_, err = svc.GetConcept(conceptURI)
+	requireAppError(t, err, outputs.ErrNotFound)
+}
+
+func TestDeleteContext_NotFound(t *testing.T) {
+	// given
+	svc := newService(t)
+	// when
+	_, err := svc.DeleteContext(contextURI, true)
+	// then
+	requireAppError(t, err, outputs.ErrNotFound)
+}
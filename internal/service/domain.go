@@ -0,0 +1,145 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// GetDomain reads the domain at rawURI, following the redirect left
+// behind if it was moved.
+func (s *Service) GetDomain(rawURI string) (*model.Domain, error) {
+	u, err := parseURI(rawURI, model.EntityTypeDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.loadDomain(s.followRedirect(u))
+}
+
+// CreateDomain stores a new domain at version 1. The context it belongs to
+// must already exist.
+func (s *Service) CreateDomain(d *model.Domain) (*model.Domain, error) {
+	u, err := parseURI(d.URI, model.EntityTypeDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if s.exists(u) {
+		return nil, alreadyExistsError(u.Raw)
+	}
+
+	now := s.clock()
+	created := *d
+	created.Entity = model.EntityTypeDomain
+	created.Schema = model.SchemaVersion
+	created.Version = 1
+	created.Created = now
+	created.LastUpdate = now
+
+	s.validator.Resolve(&created)
+
+	if err := s.checkEntity(u, &created); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveDomain(u, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// UpdateDomain replaces the stored domain, provided d.Version still matches
+// the version on disk. The URI cannot change.
+func (s *Service) UpdateDomain(d *model.Domain) (*model.Domain, error) {
+	u, err := parseURI(d.URI, model.EntityTypeDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	current, err := s.loadDomain(u)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.Version != d.Version {
+		return nil, versionConflictError(u.Raw, d.Version, current.Version)
+	}
+
+	updated := *d
+	updated.Entity = model.EntityTypeDomain
+	updated.Schema = model.SchemaVersion
+	updated.Version = current.Version + 1
+	updated.Created = current.Created
+	updated.LastUpdate = s.clock()
+
+	s.validator.Resolve(&updated)
+
+	if err := s.checkEntity(u, &updated); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveDomain(u, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteDomain removes a domain together with its concepts. If the domain
+// still contains concepts, confirm must be true.
+func (s *Service) DeleteDomain(rawURI string, confirm bool) ([]string, error) {
+	u, err := parseURI(rawURI, model.EntityTypeDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return s.deleteContainer(u, confirm)
+}
+
+func (s *Service) loadDomain(u *uri.URI) (*model.Domain, error) {
+	content, err := s.readFile(u)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := model.ParseDomain(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", u, err)
+	}
+
+	if err := checkSchema(u.Raw, d.Schema); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (s *Service) saveDomain(u *uri.URI, d *model.Domain) error {
+	content, err := model.EncodeDomain(d)
+	if err != nil {
+		return err
+	}
+
+	return s.writeFile(u, content)
+}
@@ -0,0 +1,93 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+)
+
+func TestCreateDomain(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	// when
+	d, err := svc.CreateDomain(&model.Domain{URI: contextURI + "/domains/shipping", Name: "Shipping"})
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, model.EntityTypeDomain, d.Entity)
+	assert.Equal(t, 1, d.Version)
+
+	stored, err := svc.GetDomain(contextURI + "/domains/shipping")
+	require.NoError(t, err)
+	assert.Equal(t, "Shipping", stored.Name)
+}
+
+func TestCreateDomain_ParentNotFound(t *testing.T) {
+	// given
+	svc := newService(t)
+	// when
+	_, err := svc.CreateDomain(&model.Domain{URI: domainURI, Name: "Pricing"})
+	// then
+	appErr := requireAppError(t, err, outputs.ErrParentNotFound)
+	assert.Equal(t, contextURI, appErr.Details["parent"])
+}
+
+func TestUpdateDomain(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	// when
+	updated, err := svc.UpdateDomain(&model.Domain{URI: domainURI, Name: "Pricing Rules", Version: 1})
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Version)
+}
+
+func TestUpdateDomain_VersionConflict(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	// when
+	_, err := svc.UpdateDomain(&model.Domain{URI: domainURI, Name: "Pricing Rules", Version: 2})
+	// then
+	requireAppError(t, err, outputs.ErrVersionConflict)
+}
+
+func TestDeleteDomain_Empty(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	// when
+	contained, err := svc.DeleteDomain(domainURI, false)
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, contained)
+	_, err = svc.GetDomain(domainURI)
+	requireAppError(t, err, outputs.ErrNotFound)
+	_, err = svc.GetContext(contextURI)
+	assert.NoError(t, err)
+}
+
+func TestDeleteDomain_RequiresConfirmation(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	createConcept(t, svc)
+	// when
+	_, err := svc.DeleteDomain(domainURI, false)
+	// then
+	appErr := requireAppError(t, err, outputs.ErrConfirmationRequired)
+	assert.Equal(t, []string{conceptURI}, appErr.Details["contained"])
+}
+
+func TestDeleteDomain_Confirmed(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	createConcept(t, svc)
+	// when
+	contained, err := svc.DeleteDomain(domainURI, true)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{conceptURI}, contained)
+	_, err = svc.GetConcept(conceptURI)
+	requireAppError(t, err, outputs.ErrNotFound)
+}
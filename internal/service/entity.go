@@ -0,0 +1,200 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// listURIs returns the URIs of every stored entity of the given types, or of
+// all entities when no type is given.
+func (s *Service) listURIs(entityTypes ...string) ([]*uri.URI, error) {
+	all, err := s.files.list()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entityTypes) == 0 {
+		return all, nil
+	}
+
+	return slices.DeleteFunc(all, func(u *uri.URI) bool { return !slices.Contains(entityTypes, u.Entity) }), nil
+}
+
+// loadEntity reads the entity stored at u into its model type.
+func (s *Service) loadEntity(u *uri.URI) (any, error) {
+	switch u.Entity {
+	case model.EntityTypeTag:
+		return s.loadTag(u)
+	case model.EntityTypeRelation:
+		return s.loadRelationType(u)
+	case model.EntityTypeContext:
+		return s.loadContext(u)
+	case model.EntityTypeDomain:
+		return s.loadDomain(u)
+	case model.EntityTypeConcept:
+		return s.loadConcept(u)
+	default:
+		return nil, fmt.Errorf("unsupported entity type %q", u.Entity)
+	}
+}
+
+// entityTags returns the Tags of a context, domain or concept.
+func entityTags(e any) []string {
+	switch v := e.(type) {
+	case *model.Context:
+		return v.Tags
+	case *model.Domain:
+		return v.Tags
+	case *model.Concept:
+		return v.Tags
+	default:
+		return nil
+	}
+}
+
+// entityRelations returns the Relations of a context, domain or concept.
+func entityRelations(e any) []model.RelationRef {
+	switch v := e.(type) {
+	case *model.Context:
+		return v.Relations
+	case *model.Domain:
+		return v.Relations
+	case *model.Concept:
+		return v.Relations
+	default:
+		return nil
+	}
+}
+
+// entityVersion returns the Version of any of the model types.
+func entityVersion(e any) int {
+	switch v := e.(type) {
+	case *model.Tag:
+		return v.Version
+	case *model.RelationType:
+		return v.Version
+	case *model.Context:
+		return v.Version
+	case *model.Domain:
+		return v.Version
+	case *model.Concept:
+		return v.Version
+	default:
+		return 0
+	}
+}
+
+// entitySources returns the Sources of a concept.
+func entitySources(e any) []model.Source {
+	if c, ok := e.(*model.Concept); ok {
+		return c.Sources
+	}
+
+	return nil
+}
+
+// entityLastUpdate returns the LastUpdate of any of the model types.
+func entityLastUpdate(e any) time.Time {
+	switch v := e.(type) {
+	case *model.Tag:
+		return v.LastUpdate
+	case *model.RelationType:
+		return v.LastUpdate
+	case *model.Context:
+		return v.LastUpdate
+	case *model.Domain:
+		return v.LastUpdate
+	case *model.Concept:
+		return v.LastUpdate
+	default:
+		return time.Time{}
+	}
+}
+
+// entityName returns the Name of a context, domain or concept.
+func entityName(e any) string {
+	switch v := e.(type) {
+	case *model.Context:
+		return v.Name
+	case *model.Domain:
+		return v.Name
+	case *model.Concept:
+		return v.Name
+	default:
+		return ""
+	}
+}
+
+// entityContext returns the slug of the context u belongs to; a context
+// belongs to itself.
+func entityContext(u *uri.URI) string {
+	if u.Entity == model.EntityTypeContext {
+		return u.Slug
+	}
+
+	if u.Context == nil {
+		return ""
+	}
+
+	return *u.Context
+}
+
+// saveEntity writes any of the model types at u.
+func (s *Service) saveEntity(u *uri.URI, e any) error {
+	switch v := e.(type) {
+	case *model.Tag:
+		return s.saveTag(u, v)
+	case *model.RelationType:
+		return s.saveRelationType(u, v)
+	case *model.Context:
+		return s.saveContext(u, v)
+	case *model.Domain:
+		return s.saveDomain(u, v)
+	case *model.Concept:
+		return s.saveConcept(u, v)
+	default:
+		return fmt.Errorf("unsupported entity %T", e)
+	}
+}
+
+// setEntityURI sets the URI of any of the model types.
+func setEntityURI(e any, rawURI string) {
+	switch v := e.(type) {
+	case *model.Tag:
+		v.URI = rawURI
+	case *model.RelationType:
+		v.URI = rawURI
+	case *model.Context:
+		v.URI = rawURI
+	case *model.Domain:
+		v.URI = rawURI
+	case *model.Concept:
+		v.URI = rawURI
+	}
+}
+
+// bumpVersion advances the Version of any of the model types and sets its
+// LastUpdate to now.
+func bumpVersion(e any, now time.Time) {
+	switch v := e.(type) {
+	case *model.Tag:
+		v.Version++
+		v.LastUpdate = now
+	case *model.RelationType:
+		v.Version++
+		v.LastUpdate = now
+	case *model.Context:
+		v.Version++
+		v.LastUpdate = now
+	case *model.Domain:
+		v.Version++
+		v.LastUpdate = now
+	case *model.Concept:
+		v.Version++
+		v.LastUpdate = now
+	}
+}
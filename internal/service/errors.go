@@ -0,0 +1,197 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+)
+
+func invalidURIError(raw string, err error) *outputs.AppError {
+	return &outputs.AppError{
+		Message:         err.Error(),
+		ErrorCode:       outputs.ErrInvalidURIFormat,
+		Details:         map[string]any{"uri": raw},
+		SuggestedAction: "Use a scio:// URI such as scio://contexts/<context>/domains/<domain>/concepts/<slug>",
+		Recoverable:     true,
+	}
+}
+
+func uriTypeMismatchError(raw, expected, actual string) *outputs.AppError {
+	return &outputs.AppError{
+		Message:         fmt.Sprintf("%s identifies a %s, expected a %s", raw, actual, expected),
+		ErrorCode:       outputs.ErrTypeMismatch,
+		Details:         map[string]any{"uri": raw, "expected_entity": expected, "actual_entity": actual},
+		SuggestedAction: fmt.Sprintf("Use the %s tools for this URI or pass a %s URI", actual, expected),
+		Recoverable:     true,
+	}
+}
+
+func notFoundError(raw string) *outputs.AppError {
+	return &outputs.AppError{
+		Message:         fmt.Sprintf("%s does not exist", raw),
+		ErrorCode:       outputs.ErrNotFound,
+		Details:         map[string]any{"uri": raw},
+		SuggestedAction: "Check the URI or create the entity first",
+		Recoverable:     true,
+	}
+}
+
+func versionNotFoundError(raw string, version int, available []int) *outputs.AppError {
+	return &outputs.AppError{
+		Message:   fmt.Sprintf("version %d of %s is not kept", version, raw),
+		ErrorCode: outputs.ErrNotFound,
+		Details: map[string]any{
+			"uri":                raw,
+			"version":            version,
+			"available_versions": available,
+		},
+		SuggestedAction: "List the versions of the entity and pick one of them",
+		Recoverable:     true,
+	}
+}
+
+func alreadyExistsError(raw string) *outputs.AppError {
+	return &outputs.AppError{
+		Message:         fmt.Sprintf("%s already exists", raw),
+		ErrorCode:       outputs.ErrAlreadyExists,
+		Details:         map[string]any{"uri": raw},
+		SuggestedAction: "Update the existing entity or choose a different slug",
+		Recoverable:     true,
+	}
+}
+
+func versionConflictError(raw string, expected, current int) *outputs.AppError {
+	return &outputs.AppError{
+		Message:   fmt.Sprintf("%s is at version %d, not %d", raw, current, expected),
+		ErrorCode: outputs.ErrVersionConflict,
+		Details: map[string]any{
+			"uri":              raw,
+			"expected_version": expected,
+			"current_version":  current,
+		},
+		SuggestedAction: "Re-read the entity, reapply your changes on top of the current version and retry",
+		Recoverable:     true,
+	}
+}
+
+func schemaUnsupportedError(raw string, schema int) *outputs.AppError {
+	return &outputs.AppError{
+		Message:   fmt.Sprintf("%s uses schema %d, this server supports schema %d", raw, schema, model.SchemaVersion),
+		ErrorCode: outputs.ErrSchemaUnsupported,
+		Details: map[string]any{
+			"uri":              raw,
+			"schema":           schema,
+			"supported_schema": model.SchemaVersion,
+		},
+		SuggestedAction: "Upgrade the server or migrate the file to the supported schema",
+		Recoverable:     false,
+	}
+}
+
+func checkSchema(raw string, schema int) error {
+	if schema != model.SchemaVersion {
+		return schemaUnsupportedError(raw, schema)
+	}
+
+	return nil
+}
+
+func parentNotFoundError(raw, parent string) *outputs.AppError {
+	return &outputs.AppError{
+		Message:         fmt.Sprintf("parent %s of %s does not exist", parent, raw),
+		ErrorCode:       outputs.ErrParentNotFound,
+		Details:         map[string]any{"uri": raw, "parent": parent},
+		SuggestedAction: fmt.Sprintf("Create %s first", parent),
+		Recoverable:     true,
+	}
+}
+
+func confirmationRequiredError(raw string, contained []string) *outputs.AppError {
+	return &outputs.AppError{
+		Message:   fmt.Sprintf("%s still contains %d entities that would be deleted with it", raw, len(contained)),
+		ErrorCode: outputs.ErrConfirmationRequired,
+		Details: map[string]any{
+			"uri":       raw,
+			"contained": contained,
+		},
+		SuggestedAction: "Review the contained entities and retry with confirm set to true to delete them all",
+		Recoverable:     true,
+	}
+}
+
+func validationError(raw, message string, details map[string]any) *outputs.AppError {
+	details["uri"] = raw
+
+	return &outputs.AppError{
+		Message:         fmt.Sprintf("%s: %s", raw, message),
+		ErrorCode:       outputs.ErrValidationFailed,
+		Details:         details,
+		SuggestedAction: "Fix the reported fields and retry",
+		Recoverable:     true,
+	}
+}
+
+func tagCycleError(raw string, cycle []string) *outputs.AppError {
+	return &outputs.AppError{
+		Message:         fmt.Sprintf("the broader/narrower hierarchy of %s would contain a loop", raw),
+		ErrorCode:       outputs.ErrTagCycle,
+		Details:         map[string]any{"uri": raw, "cycle": cycle},
+		SuggestedAction: "Remove one of the broader or narrower links along the reported cycle",
+		Recoverable:     true,
+	}
+}
+
+func tagInUseError(raw string, referencedBy []string) *outputs.AppError {
+	return &outputs.AppError{
+		Message:         fmt.Sprintf("tag %s is still used by %d entities", raw, len(referencedBy)),
+		ErrorCode:       outputs.ErrTagInUse,
+		Details:         map[string]any{"uri": raw, "referenced_by": referencedBy},
+		SuggestedAction: "Remove the tag from the referencing entities before deleting it",
+		Recoverable:     true,
+	}
+}
+
+func relationPropertiesConflictError(raw, message string, details map[string]any) *outputs.AppError {
+	details["uri"] = raw
+
+	return &outputs.AppError{
+		Message:         fmt.Sprintf("%s: %s", raw, message),
+		ErrorCode:       outputs.ErrRelationPropertiesConflict,
+		Details:         details,
+		SuggestedAction: "Adjust symmetric, transitive, inverse_of or the allowed entity lists so both relation types agree",
+		Recoverable:     true,
+	}
+}
+
+func relationInUseError(raw string, referencedBy []string) *outputs.AppError {
+	return &outputs.AppError{
+		Message:         fmt.Sprintf("relation type %s is still used by %d entities", raw, len(referencedBy)),
+		ErrorCode:       outputs.ErrRelationInUse,
+		Details:         map[string]any{"uri": raw, "referenced_by": referencedBy},
+		SuggestedAction: "Remove the relations of this type from the referencing entities before deleting it",
+		Recoverable:     true,
+	}
+}
+
+func batchValidationFailedError(total int, failures []BatchFailure) *outputs.AppError {
+	return &outputs.AppError{
+		Message:         fmt.Sprintf("batch of %d operations has %d failures, nothing was written", total, len(failures)),
+		ErrorCode:       outputs.ErrBatchValidationFailed,
+		Details:         map[string]any{"failures": failures},
+		SuggestedAction: "Fix the reported operations and resubmit the whole batch",
+		Recoverable:     true,
+	}
+}
+
+func invalidArgumentError(field, message string, details map[string]any) *outputs.AppError {
+	details["field"] = field
+
+	return &outputs.AppError{
+		Message:         message,
+		ErrorCode:       outputs.ErrValidationFailed,
+		Details:         details,
+		SuggestedAction: fmt.Sprintf("Fix %s and retry", field),
+		Recoverable:     true,
+	}
+}
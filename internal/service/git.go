@@ -0,0 +1,108 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/git"
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// WithGit makes the service commit every file it writes or removes to repo,
+// with a message naming the operation, the URI, the old and new version and
+// the actor.
+func WithGit(repo *git.Repo) Option {
+	return func(s *Service) {
+		s.git = repo
+	}
+}
+
+// gitStore commits every successful write and removal of its store.
+type gitStore struct {
+	store
+	rootDir string
+	repo    *git.Repo
+}
+
+func (g gitStore) write(u *uri.URI, content []byte) error {
+	op := git.OpCreate
+	oldVersion := 0
+
+	if g.exists(u) {
+		op = git.OpUpdate
+		oldVersion = g.storedVersion(u)
+	}
+
+	if err := g.store.write(u, content); err != nil {
+		return err
+	}
+
+	newVersion, err := model.ParseVersion(string(content))
+	if err != nil {
+		return fmt.Errorf("failed to commit %s: %w", u, err)
+	}
+
+	return g.commit(op, u, oldVersion, newVersion, storage.FileName(g.rootDir, u))
+}
+
+func (g gitStore) remove(u *uri.URI) error {
+	oldVersion := g.storedVersion(u)
+
+	if err := g.store.remove(u); err != nil {
+		return err
+	}
+
+	path := storage.FileName(g.rootDir, u)
+	if u.Entity == model.EntityTypeContext || u.Entity == model.EntityTypeDomain {
+		path = storage.FileDir(g.rootDir, u)
+	}
+
+	return g.commit(git.OpDelete, u, oldVersion, 0, path)
+}
+
+// storedVersion returns the version of the stored entity, or 0 if it cannot
+// be read.
+func (g gitStore) storedVersion(u *uri.URI) int {
+	content, err := g.read(u)
+	if err != nil {
+		return 0
+	}
+
+	version, err := model.ParseVersion(string(content))
+	if err != nil {
+		return 0
+	}
+
+	return version
+}
+
+func (g gitStore) commit(op string, u *uri.URI, oldVersion, newVersion int, path string) error {
+	rel, err := filepath.Rel(g.rootDir, path)
+	if err != nil {
+		return fmt.Errorf("failed to commit %s: %w", u, err)
+	}
+
+	if err := g.repo.Commit(git.Message(op, u.Raw, oldVersion, newVersion, g.repo.Actor()), rel); err != nil {
+		return fmt.Errorf("failed to commit %s: %w", u, err)
+	}
+
+	return nil
+}
+
+// commitRedirectsFile commits the redirects file when git history is
+// enabled.
+func (s *Service) commitRedirectsFile() error {
+	if s.git == nil {
+		return nil
+	}
+
+	message := fmt.Sprintf("update redirects\n\nOperation: %s\nActor: %s\n", git.OpRedirects, s.git.Actor())
+
+	if err := s.git.Commit(message, storage.RedirectsFile); err != nil {
+		return fmt.Errorf("failed to commit redirects: %w", err)
+	}
+
+	return nil
+}
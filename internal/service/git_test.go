@@ -0,0 +1,111 @@
+package service_test
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/git"
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+)
+
+// newGitService returns a service committing every write to a fresh local
+// repository in its root directory.
+func newGitService(t *testing.T) *service.Service {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	require.NoError(t, storage.InitRootDirs(root))
+
+	repo, err := git.Open(root, "alice")
+	require.NoError(t, err)
+
+	return service.New(root, service.WithGit(repo))
+}
+
+// gitLog returns the full message of every commit touching path in the
+// repository at dir, newest first, or of every commit when path is empty.
+func gitLog(t *testing.T, dir, path string) []string {
+	t.Helper()
+
+	args := []string{"-C", dir, "log", "--format=%B%x00"}
+	if path != "" {
+		args = append(args, "--", path)
+	}
+
+	out, err := exec.Command("git", args...).Output()
+	require.NoError(t, err)
+
+	var messages []string
+
+	for _, message := range strings.Split(string(out), "\x00") {
+		if message = strings.TrimSpace(message); message != "" {
+			messages = append(messages, message)
+		}
+	}
+
+	return messages
+}
+
+func TestGitHistory_EntityLifecycle(t *testing.T) {
+	// given
+	svc := newGitService(t)
+	_, err := svc.CreateContext(&model.Context{URI: contextURI})
+	require.NoError(t, err)
+	_, err = svc.CreateDomain(&model.Domain{URI: domainURI})
+	require.NoError(t, err)
+	c, err := svc.CreateConcept(&model.Concept{URI: conceptURI, Name: "Discount"})
+	require.NoError(t, err)
+	// when
+	c.Name = "Discounts"
+	_, err = svc.UpdateConcept(c)
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteConcept(conceptURI))
+	// then
+	log := gitLog(t, svc.RootDir(), "contexts/ecommerce/domains/pricing/discount.md")
+	assert.Equal(t, []string{
+		"delete " + conceptURI + " (v2)\n\nOperation: delete\nURI: " + conceptURI + "\nOld-Version: 2\nNew-Version: 0\nActor: alice",
+		"update " + conceptURI + " (v1 -> v2)\n\nOperation: update\nURI: " + conceptURI + "\nOld-Version: 1\nNew-Version: 2\nActor: alice",
+		"create " + conceptURI + " (v1)\n\nOperation: create\nURI: " + conceptURI + "\nOld-Version: 0\nNew-Version: 1\nActor: alice",
+	}, log)
+}
+
+func TestGitHistory_ContainerDelete(t *testing.T) {
+	// given
+	svc := newGitService(t)
+	_, err := svc.CreateContext(&model.Context{URI: contextURI})
+	require.NoError(t, err)
+	_, err = svc.CreateDomain(&model.Domain{URI: domainURI})
+	require.NoError(t, err)
+	// when
+	_, err = svc.DeleteContext(contextURI, true)
+	// then
+	require.NoError(t, err)
+	log := gitLog(t, svc.RootDir(), "contexts/ecommerce/domains/pricing/domain.md")
+	require.Len(t, log, 2)
+	assert.Contains(t, log[0], "delete "+contextURI+" (v1)")
+}
+
+func TestGitHistory_BatchAndMove(t *testing.T) {
+	// given
+	svc := newGitService(t)
+	populateGraph(t, svc)
+	// when
+	_, err := svc.Move(voucherURI, domainURI+"/concepts/gift-card", 1)
+	// then
+	require.NoError(t, err)
+	log := gitLog(t, svc.RootDir(), "")
+	assert.Contains(t, log[0], "Operation: redirects")
+	assert.Contains(t, log[1], "update "+otherConceptURI+" (v1 -> v2)")
+	assert.Contains(t, log[2], "delete "+voucherURI+" (v1)")
+	assert.Contains(t, log[3], "create "+domainURI+"/concepts/gift-card (v2)")
+}
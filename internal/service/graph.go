@@ -0,0 +1,315 @@
+package service
+
+import (
+	"slices"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/index"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// Directions a traversal may follow relations in.
+const (
+	DirectionOutgoing = "outgoing"
+	DirectionIncoming = "incoming"
+	DirectionBoth     = "both"
+)
+
+const (
+	defaultGraphDepth = 1
+	maxGraphDepth     = 5
+)
+
+var directions = []string{DirectionOutgoing, DirectionIncoming, DirectionBoth}
+
+// GraphQuery selects the subgraph around URI. Depth is the number of
+// relation hops to follow, 1 by default. RelationTypes and EntityTypes
+// restrict the edges followed and the nodes reached; empty filters allow
+// everything. Infer adds the edges implied by symmetric, inverse and
+// transitive relation types.
+type GraphQuery struct {
+	URI           string
+	Depth         int
+	Direction     string
+	RelationTypes []string
+	EntityTypes   []string
+	Infer         bool
+}
+
+// GraphNode is an entity of a subgraph. Missing marks relation targets that
+// do not exist.
+type GraphNode struct {
+	URI     string `json:"uri"`
+	Entity  string `json:"entity"`
+	Name    string `json:"name,omitempty"`
+	Depth   int    `json:"depth"`
+	Missing bool   `json:"missing,omitempty"`
+}
+
+// GraphEdge is a relation of Type held by Source and pointing at Target.
+// Inferred edges are not stored but implied by the relation types of the
+// asserted edges listed in Justification.
+type GraphEdge struct {
+	Source        string         `json:"source"`
+	Type          string         `json:"type"`
+	Target        string         `json:"target"`
+	Inferred      bool           `json:"inferred,omitempty"`
+	Justification []AssertedEdge `json:"justification,omitempty"`
+}
+
+// AssertedEdge is a relation stored in the Relations of its Source.
+type AssertedEdge struct {
+	Source string `json:"source"`
+	Type   string `json:"type"`
+	Target string `json:"target"`
+}
+
+// Graph is a subgraph of the relations between entities.
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// relationGraph looks up the relations around an entity.
+type relationGraph interface {
+	outgoing(raw string) ([]GraphEdge, error)
+	incoming(raw string) ([]GraphEdge, error)
+}
+
+// Graph walks the relations around q.URI breadth first and returns the
+// nodes reached and the edges followed.
+func (s *Service) Graph(q GraphQuery) (*Graph, error) {
+	start, err := uri.Parse(q.URI)
+	if err != nil {
+		return nil, invalidURIError(q.URI, err)
+	}
+
+	depth := q.Depth
+	if depth == 0 {
+		depth = defaultGraphDepth
+	}
+
+	if depth < 0 || depth > maxGraphDepth {
+		return nil, invalidArgumentError("depth", "depth must be between 1 and 5", map[string]any{"value": q.Depth})
+	}
+
+	direction := q.Direction
+	if direction == "" {
+		direction = DirectionBoth
+	}
+
+	if !slices.Contains(directions, direction) {
+		return nil, invalidArgumentError("direction", "direction must be outgoing, incoming or both", map[string]any{
+			"value":   q.Direction,
+			"allowed": directions,
+		})
+	}
+
+	if !s.exists(start) {
+		return nil, notFoundError(start.Raw)
+	}
+
+	relations, err := s.relationGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	if q.Infer {
+		if relations, err = s.newRelationReasoner(relations); err != nil {
+			return nil, err
+		}
+	}
+
+	w := graphWalk{
+		service:   s,
+		relations: relations,
+		query:     q,
+		direction: direction,
+		graph:     Graph{Nodes: []GraphNode{}, Edges: []GraphEdge{}},
+		seenNodes: make(map[string]bool),
+		seenEdges: make(map[edgeKey]bool),
+	}
+
+	if err := w.walk(start, depth); err != nil {
+		return nil, err
+	}
+
+	return &w.graph, nil
+}
+
+type graphWalk struct {
+	service   *Service
+	relations relationGraph
+	query     GraphQuery
+	direction string
+	graph     Graph
+	seenNodes map[string]bool
+	seenEdges map[edgeKey]bool
+}
+
+func (w *graphWalk) walk(start *uri.URI, depth int) error {
+	if err := w.addNode(start, 0); err != nil {
+		return err
+	}
+
+	frontier := []string{start.Raw}
+
+	for level := 1; level <= depth && len(frontier) > 0; level++ {
+		var next []string
+
+		for _, raw := range frontier {
+			edges, err := w.edgesOf(raw)
+			if err != nil {
+				return err
+			}
+
+			for _, e := range edges {
+				if len(w.query.RelationTypes) > 0 && !slices.Contains(w.query.RelationTypes, e.Type) {
+					continue
+				}
+
+				other := e.Target
+				if other == raw {
+					other = e.Source
+				}
+
+				u, err := uri.Parse(other)
+				if err != nil {
+					continue
+				}
+
+				if len(w.query.EntityTypes) > 0 && !slices.Contains(w.query.EntityTypes, u.Entity) {
+					continue
+				}
+
+				if !w.seenEdges[keyOf(e)] {
+					w.seenEdges[keyOf(e)] = true
+					w.graph.Edges = append(w.graph.Edges, e)
+				}
+
+				if w.seenNodes[u.Raw] {
+					continue
+				}
+
+				if err := w.addNode(u, level); err != nil {
+					return err
+				}
+
+				next = append(next, u.Raw)
+			}
+		}
+
+		frontier = next
+	}
+
+	return nil
+}
+
+func (w *graphWalk) edgesOf(raw string) ([]GraphEdge, error) {
+	var edges []GraphEdge
+
+	if w.direction != DirectionIncoming {
+		out, err := w.relations.outgoing(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		edges = append(edges, out...)
+	}
+
+	if w.direction != DirectionOutgoing {
+		in, err := w.relations.incoming(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		edges = append(edges, in...)
+	}
+
+	return edges, nil
+}
+
+func (w *graphWalk) addNode(u *uri.URI, depth int) error {
+	w.seenNodes[u.Raw] = true
+	node := GraphNode{URI: u.Raw, Entity: u.Entity, Depth: depth}
+
+	if w.service.exists(u) {
+		e, err := w.service.loadEntity(u)
+		if err != nil {
+			return err
+		}
+
+		node.Name = entityName(e)
+	} else {
+		node.Missing = true
+	}
+
+	w.graph.Nodes = append(w.graph.Nodes, node)
+	return nil
+}
+
+// relationGraph returns the relations of the store, answered by the index
+// when the service has one and otherwise loaded from every entity.
+func (s *Service) relationGraph() (relationGraph, error) {
+	if s.index != nil {
+		return indexGraph{index: s.index}, nil
+	}
+
+	uris, err := s.listURIs(contentEntities...)
+	if err != nil {
+		return nil, err
+	}
+
+	g := memoryGraph{out: make(map[string][]GraphEdge), in: make(map[string][]GraphEdge)}
+
+	for _, u := range uris {
+		e, err := s.loadEntity(u)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ref := range entityRelations(e) {
+			edge := GraphEdge{Source: u.Raw, Type: ref.Type, Target: ref.Target}
+			g.out[edge.Source] = append(g.out[edge.Source], edge)
+			g.in[edge.Target] = append(g.in[edge.Target], edge)
+		}
+	}
+
+	return g, nil
+}
+
+type indexGraph struct {
+	index *index.Index
+}
+
+func (g indexGraph) outgoing(raw string) ([]GraphEdge, error) {
+	edges, err := g.index.Outgoing(raw)
+	return graphEdges(edges), err
+}
+
+func (g indexGraph) incoming(raw string) ([]GraphEdge, error) {
+	edges, err := g.index.Incoming(raw)
+	return graphEdges(edges), err
+}
+
+func graphEdges(edges []index.Edge) []GraphEdge {
+	converted := make([]GraphEdge, 0, len(edges))
+
+	for _, e := range edges {
+		converted = append(converted, GraphEdge{Source: e.Source, Type: e.Type, Target: e.Target})
+	}
+
+	return converted
+}
+
+type memoryGraph struct {
+	out map[string][]GraphEdge
+	in  map[string][]GraphEdge
+}
+
+func (g memoryGraph) outgoing(raw string) ([]GraphEdge, error) {
+	return g.out[raw], nil
+}
+
+func (g memoryGraph) incoming(raw string) ([]GraphEdge, error) {
+	return g.in[raw], nil
+}
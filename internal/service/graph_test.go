@@ -0,0 +1,159 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+const (
+	voucherURI  = domainURI + "/concepts/voucher"
+	campaignURI = domainURI + "/concepts/campaign"
+)
+
+// populateGraph stores the relations
+//
+//	discount -depends-on-> coupon -depends-on-> voucher
+//	campaign -part-of-> discount -part-of-> pricing domain
+func populateGraph(t *testing.T, svc *service.Service) {
+	t.Helper()
+
+	related := func(rel, target string) []model.RelationRef {
+		return []model.RelationRef{{Type: rel, Target: target}}
+	}
+
+	_, err := svc.Batch([]service.BatchOperation{
+		{Op: service.BatchCreate, URI: partOfRelation, Entity: &model.RelationType{URI: partOfRelation, Transitive: true}},
+		{Op: service.BatchCreate, URI: dependsRelation, Entity: &model.RelationType{URI: dependsRelation}},
+		{Op: service.BatchCreate, URI: contextURI, Entity: &model.Context{URI: contextURI, Name: "E-commerce"}},
+		{Op: service.BatchCreate, URI: domainURI, Entity: &model.Domain{URI: domainURI, Name: "Pricing"}},
+		{Op: service.BatchCreate, URI: conceptURI, Entity: &model.Concept{
+			URI:  conceptURI,
+			Name: "Discount",
+			Relations: []model.RelationRef{
+				{Type: dependsRelation, Target: otherConceptURI},
+				{Type: partOfRelation, Target: domainURI},
+			},
+		}},
+		{Op: service.BatchCreate, URI: otherConceptURI, Entity: &model.Concept{URI: otherConceptURI, Name: "Coupon", Relations: related(dependsRelation, voucherURI)}},
+		{Op: service.BatchCreate, URI: voucherURI, Entity: &model.Concept{URI: voucherURI, Name: "Voucher"}},
+		{Op: service.BatchCreate, URI: campaignURI, Entity: &model.Concept{URI: campaignURI, Name: "Campaign", Relations: related(partOfRelation, conceptURI)}},
+	})
+	require.NoError(t, err)
+}
+
+func nodeURIs(g *service.Graph) []string {
+	uris := make([]string, 0, len(g.Nodes))
+	for _, n := range g.Nodes {
+		uris = append(uris, n.URI)
+	}
+
+	return uris
+}
+
+func TestGraph(t *testing.T) {
+	services := map[string]func(t *testing.T) *service.Service{
+		"scan": newService,
+		"index": func(t *testing.T) *service.Service {
+			svc, _ := newIndexedService(t)
+			return svc
+		},
+	}
+
+	for name, newSvc := range services {
+		t.Run(name, func(t *testing.T) {
+			// given
+			svc := newSvc(t)
+			populateGraph(t, svc)
+			// when
+			g, err := svc.Graph(service.GraphQuery{URI: conceptURI})
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, []service.GraphNode{
+				{URI: conceptURI, Entity: model.EntityTypeConcept, Name: "Discount", Depth: 0},
+				{URI: otherConceptURI, Entity: model.EntityTypeConcept, Name: "Coupon", Depth: 1},
+				{URI: domainURI, Entity: model.EntityTypeDomain, Name: "Pricing", Depth: 1},
+				{URI: campaignURI, Entity: model.EntityTypeConcept, Name: "Campaign", Depth: 1},
+			}, g.Nodes)
+			assert.Equal(t, []service.GraphEdge{
+				{Source: conceptURI, Type: dependsRelation, Target: otherConceptURI},
+				{Source: conceptURI, Type: partOfRelation, Target: domainURI},
+				{Source: campaignURI, Type: partOfRelation, Target: conceptURI},
+			}, g.Edges)
+		})
+	}
+}
+
+func TestGraph_Queries(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    service.GraphQuery
+		expected []string
+	}{
+		{
+			name:     "outgoing two hops",
+			query:    service.GraphQuery{URI: conceptURI, Depth: 2, Direction: service.DirectionOutgoing},
+			expected: []string{conceptURI, otherConceptURI, domainURI, voucherURI},
+		},
+		{
+			name:     "incoming",
+			query:    service.GraphQuery{URI: voucherURI, Depth: 3, Direction: service.DirectionIncoming},
+			expected: []string{voucherURI, otherConceptURI, conceptURI, campaignURI},
+		},
+		{
+			name:     "relation types",
+			query:    service.GraphQuery{URI: campaignURI, Depth: 5, RelationTypes: []string{partOfRelation}},
+			expected: []string{campaignURI, conceptURI, domainURI},
+		},
+		{
+			name:     "entity types",
+			query:    service.GraphQuery{URI: conceptURI, Depth: 2, EntityTypes: []string{model.EntityTypeDomain}},
+			expected: []string{conceptURI, domainURI},
+		},
+		{
+			name:     "start without relations",
+			query:    service.GraphQuery{URI: contextURI},
+			expected: []string{contextURI},
+		},
+	}
+
+	svc, _ := newIndexedService(t)
+	populateGraph(t, svc)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := svc.Graph(tc.query)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, nodeURIs(g))
+		})
+	}
+}
+
+func TestGraph_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query service.GraphQuery
+		code  string
+	}{
+		{"invalid URI", service.GraphQuery{URI: "nowhere"}, outputs.ErrInvalidURIFormat},
+		{"missing start", service.GraphQuery{URI: conceptURI}, outputs.ErrNotFound},
+		{"depth too large", service.GraphQuery{URI: contextURI, Depth: 6}, outputs.ErrValidationFailed},
+		{"unknown direction", service.GraphQuery{URI: contextURI, Direction: "up"}, outputs.ErrValidationFailed},
+	}
+
+	svc := newService(t)
+	_, err := svc.CreateContext(&model.Context{URI: contextURI})
+	require.NoError(t, err)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.Graph(tc.query)
+			requireAppError(t, err, tc.code)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+)
+
+// newService returns a service backed by an initialized, empty store.
+func newService(t *testing.T) *service.Service {
+	t.Helper()
+
+	root := t.TempDir()
+	require.NoError(t, storage.InitRootDirs(root))
+	return service.New(root)
+}
+
+// requireAppError asserts that err is an AppError with the given code and
+// returns it for further inspection.
+func requireAppError(t *testing.T, err error, code string) *outputs.AppError {
+	t.Helper()
+
+	var appErr *outputs.AppError
+	require.True(t, errors.As(err, &appErr), "expected an AppError, got %v", err)
+	require.Equal(t, code, appErr.ErrorCode, appErr.Message)
+	return appErr
+}
+
+const (
+	contextURI = "scio://contexts/ecommerce"
+	domainURI  = "scio://contexts/ecommerce/domains/pricing"
+)
+
+// newServiceWithDomain returns a service whose store already holds the
+// contextURI context and the domainURI domain.
+func newServiceWithDomain(t *testing.T) *service.Service {
+	t.Helper()
+
+	svc := newService(t)
+
+	_, err := svc.CreateContext(&model.Context{URI: contextURI, Name: "E-commerce"})
+	require.NoError(t, err)
+
+	_, err = svc.CreateDomain(&model.Domain{URI: domainURI, Name: "Pricing"})
+	require.NoError(t, err)
+
+	return svc
+}
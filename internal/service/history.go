@@ -0,0 +1,396 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// EntityVersion describes one version kept of an entity. Current is set on
+// the version stored in the entity file.
+type EntityVersion struct {
+	Version    int       `json:"version"`
+	LastUpdate time.Time `json:"last_update"`
+	Current    bool      `json:"current"`
+}
+
+// EntityDiff lists what changed in an entity between two of its versions.
+// Fields holds every other top-level field whose value changed, and Body is
+// a unified diff of the markdown body, empty when the body did not change.
+type EntityDiff struct {
+	URI       string        `json:"uri"`
+	From      int           `json:"from"`
+	To        int           `json:"to"`
+	Tags      TagsDiff      `json:"tags"`
+	Relations RelationsDiff `json:"relations"`
+	Sources   SourcesDiff   `json:"sources"`
+	Fields    []FieldChange `json:"fields"`
+	Body      string        `json:"body"`
+}
+
+type TagsDiff struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+type RelationsDiff struct {
+	Added   []model.RelationRef `json:"added"`
+	Removed []model.RelationRef `json:"removed"`
+}
+
+type SourcesDiff struct {
+	Added   []model.Source `json:"added"`
+	Removed []model.Source `json:"removed"`
+}
+
+// FieldChange is a field whose value changed, with both values rendered as
+// text; lists are rendered as JSON.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// historyStore keeps a snapshot of every version written to its store, so
+// that past versions can be listed and compared. While a transaction is
+// being committed its snapshots are held back in pending, and kept only once
+// every change of the transaction was applied.
+type historyStore struct {
+	store
+	rootDir string
+	pending *pendingSnapshots
+}
+
+// pendingSnapshots holds the snapshots of the transaction being committed.
+// It is only used under the store lock.
+type pendingSnapshots struct {
+	held      bool
+	snapshots []func() error
+}
+
+// write keeps the snapshot of content before replacing the file, so that a
+// version is never written without its snapshot. Snapshots held back by a
+// transaction are kept after every change of the transaction was applied.
+func (h historyStore) write(u *uri.URI, content []byte) error {
+	created := !h.store.exists(u)
+
+	version, err := model.ParseVersion(string(content))
+	if err != nil {
+		return fmt.Errorf("failed to keep history of %s: %w", u, err)
+	}
+
+	keep := func() error {
+		if err := h.keepSnapshot(u, version, content, created); err != nil {
+			return fmt.Errorf("failed to keep history of %s: %w", u, err)
+		}
+
+		return nil
+	}
+
+	if h.pending != nil && h.pending.held {
+		if err := h.store.write(u, content); err != nil {
+			return err
+		}
+
+		h.pending.snapshots = append(h.pending.snapshots, keep)
+		return nil
+	}
+
+	if err := keep(); err != nil {
+		return err
+	}
+
+	return h.store.write(u, content)
+}
+
+// keepSnapshot saves content as the given version of the entity at u. When
+// the entity was created at a URI whose history belongs to an entity deleted
+// before, that history is archived first. Restoring a deleted entity as it
+// was, as a rolled back transaction does, keeps its history.
+func (h historyStore) keepSnapshot(u *uri.URI, version int, content []byte, created bool) error {
+	if created {
+		kept, err := storage.ReadSnapshot(h.rootDir, u, version)
+		if err != nil || !bytes.Equal(kept, content) {
+			if _, err := storage.ArchiveHistory(h.rootDir, u); err != nil {
+				return err
+			}
+		}
+	}
+
+	return storage.SaveSnapshot(h.rootDir, u, version, content)
+}
+
+// commit applies overlay to the store of the service. The snapshots of the
+// versions it writes are kept once every change was applied, and dropped
+// with the changes when they are rolled back.
+func (s *Service) commit(overlay *overlayStore) error {
+	s.snapshots.held = true
+	err := overlay.commit()
+	snapshots := s.snapshots.snapshots
+	s.snapshots.held, s.snapshots.snapshots = false, nil
+
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, keep := range snapshots {
+		errs = append(errs, keep())
+	}
+
+	return errors.Join(errs...)
+}
+
+// History lists the versions kept of the entity at rawURI, oldest first.
+// Deleting an entity keeps its history, and an entity written before
+// history was kept lists only its current version. URIs retired by a move or
+// merge are not followed: their history is the one written under them.
+func (s *Service) History(rawURI string) ([]EntityVersion, error) {
+	u, err := uri.Parse(rawURI)
+	if err != nil {
+		return nil, invalidURIError(rawURI, err)
+	}
+
+	versions, current, err := s.versions(u)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		return nil, notFoundError(u.Raw)
+	}
+
+	history := make([]EntityVersion, 0, len(versions))
+
+	for _, version := range versions {
+		e, err := s.entityAt(u, version)
+		if err != nil {
+			return nil, err
+		}
+
+		history = append(history, EntityVersion{
+			Version:    version,
+			LastUpdate: entityLastUpdate(e),
+			Current:    version == current,
+		})
+	}
+
+	return history, nil
+}
+
+// Diff compares two versions of the entity at rawURI. Swapping from and to
+// reverses every change.
+func (s *Service) Diff(rawURI string, from, to int) (*EntityDiff, error) {
+	u, err := uri.Parse(rawURI)
+	if err != nil {
+		return nil, invalidURIError(rawURI, err)
+	}
+
+	before, err := s.entityAt(u, from)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := s.entityAt(u, to)
+	if err != nil {
+		return nil, err
+	}
+
+	beforeFields, err := entityFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := entityFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &EntityDiff{URI: u.Raw, From: from, To: to, Fields: []FieldChange{}}
+	diff.Tags.Added, diff.Tags.Removed = addedRemoved(entityTags(before), entityTags(after))
+	diff.Relations.Added, diff.Relations.Removed = addedRemoved(entityRelations(before), entityRelations(after))
+	diff.Sources.Added, diff.Sources.Removed = addedRemoved(entitySources(before), entitySources(after))
+
+	for _, field := range changedFields(beforeFields, afterFields) {
+		diff.Fields = append(diff.Fields, FieldChange{
+			Field: field,
+			From:  fieldText(beforeFields[field]),
+			To:    fieldText(afterFields[field]),
+		})
+	}
+
+	diff.Body = unifiedDiff(
+		fmt.Sprintf("%s v%d", u.Raw, from),
+		fmt.Sprintf("%s v%d", u.Raw, to),
+		fieldText(beforeFields["body"]),
+		fieldText(afterFields["body"]),
+	)
+
+	return diff, nil
+}
+
+// versions returns the versions kept of the entity at u in ascending order,
+// and the version of its file, or 0 if it has none.
+func (s *Service) versions(u *uri.URI) ([]int, int, error) {
+	versions, err := storage.SnapshotVersions(s.rootDir, u)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read history of %s: %w", u, err)
+	}
+
+	if !s.exists(u) {
+		return versions, 0, nil
+	}
+
+	e, err := s.loadEntity(u)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	current := entityVersion(e)
+
+	if !slices.Contains(versions, current) {
+		versions = append(versions, current)
+		slices.Sort(versions)
+	}
+
+	return versions, current, nil
+}
+
+// entityAt returns the given version of the entity at u, from its snapshot
+// or, failing that, from its file.
+func (s *Service) entityAt(u *uri.URI, version int) (any, error) {
+	content, err := storage.ReadSnapshot(s.rootDir, u, version)
+	if err == nil {
+		return decodeEntity(u, string(content))
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read version %d of %s: %w", version, u, err)
+	}
+
+	versions, current, err := s.versions(u)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != current || current == 0 {
+		return nil, versionNotFoundError(u.Raw, version, versions)
+	}
+
+	return s.loadEntity(u)
+}
+
+// decodeEntity parses content as the model type of the entity at u.
+func decodeEntity(u *uri.URI, content string) (any, error) {
+	var (
+		e   any
+		err error
+	)
+
+	switch u.Entity {
+	case model.EntityTypeTag:
+		e, err = model.ParseTag(content)
+	case model.EntityTypeRelation:
+		e, err = model.ParseRelationType(content)
+	case model.EntityTypeContext:
+		e, err = model.ParseContext(content)
+	case model.EntityTypeDomain:
+		e, err = model.ParseDomain(content)
+	case model.EntityTypeConcept:
+		e, err = model.ParseConcept(content)
+	default:
+		return nil, fmt.Errorf("unsupported entity type %q", u.Entity)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", u, err)
+	}
+
+	return e, nil
+}
+
+// entityFields returns the fields of e keyed by their JSON names.
+func entityFields(e any) (map[string]any, error) {
+	encoded, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// listedFields are compared item by item rather than as field changes.
+var listedFields = []string{"tags", "relations", "sources", "body"}
+
+// changedFields returns the sorted names of the fields whose values differ,
+// other than the listed fields.
+func changedFields(before, after map[string]any) []string {
+	var changed []string
+
+	for field := range before {
+		if _, ok := after[field]; !ok && !slices.Contains(listedFields, field) {
+			changed = append(changed, field)
+		}
+	}
+
+	for field, value := range after {
+		if !slices.Contains(listedFields, field) && fieldText(value) != fieldText(before[field]) {
+			changed = append(changed, field)
+		}
+	}
+
+	sort.Strings(changed)
+	return changed
+}
+
+func fieldText(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+
+		return string(encoded)
+	}
+}
+
+// addedRemoved returns the items of after missing from before, and the
+// items of before missing from after.
+func addedRemoved[T comparable](before, after []T) ([]T, []T) {
+	added := []T{}
+	removed := []T{}
+
+	for _, item := range after {
+		if !slices.Contains(before, item) {
+			added = append(added, item)
+		}
+	}
+
+	for _, item := range before {
+		if !slices.Contains(after, item) {
+			removed = append(removed, item)
+		}
+	}
+
+	return added, removed
+}
@@ -0,0 +1,251 @@
+package service_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+// editConcept creates the concept at conceptURI and updates it twice,
+// leaving it at version 3.
+func editConcept(t *testing.T, svc *service.Service) {
+	t.Helper()
+
+	_, err := svc.CreateTag(&model.Tag{URI: pricingTag})
+	require.NoError(t, err)
+	_, err = svc.CreateTag(&model.Tag{URI: discountTag})
+	require.NoError(t, err)
+	_, err = svc.CreateRelationType(&model.RelationType{URI: dependsRelation})
+	require.NoError(t, err)
+
+	c, err := svc.CreateConcept(&model.Concept{
+		URI:     conceptURI,
+		Name:    "Discount",
+		Tags:    []string{pricingTag},
+		Sources: []model.Source{{Type: "doc", Href: "a.md"}},
+		Body:    "# Discount\n\nOne line.\nTwo line.\nThree line.\n",
+	})
+	require.NoError(t, err)
+
+	c.Name = "Discounts"
+	c, err = svc.UpdateConcept(c)
+	require.NoError(t, err)
+
+	c.Tags = []string{discountTag}
+	c.Relations = []model.RelationRef{{Type: dependsRelation, Target: domainURI}}
+	c.Sources = []model.Source{{Type: "doc", Href: "b.md"}}
+	c.Body = "# Discount\n\nOne line.\nTwo lines.\nThree line.\n"
+	_, err = svc.UpdateConcept(c)
+	require.NoError(t, err)
+}
+
+func TestHistory(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	editConcept(t, svc)
+	// when
+	history, err := svc.History(conceptURI)
+	// then
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+
+	for i, v := range history {
+		assert.Equal(t, i+1, v.Version)
+		assert.Equal(t, i == 2, v.Current)
+		assert.False(t, v.LastUpdate.IsZero())
+	}
+}
+
+func TestHistory_Deleted(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	editConcept(t, svc)
+	require.NoError(t, svc.DeleteConcept(conceptURI))
+	// when
+	history, err := svc.History(conceptURI)
+	// then
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.False(t, history[2].Current)
+}
+
+func TestHistory_Recreated(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	editConcept(t, svc)
+	require.NoError(t, svc.DeleteConcept(conceptURI))
+	_, err := svc.CreateConcept(&model.Concept{URI: conceptURI, Name: "Rebate"})
+	require.NoError(t, err)
+	// when
+	history, err := svc.History(conceptURI)
+	// then
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.True(t, history[0].Current)
+	assert.FileExists(t, filepath.Join(svc.RootDir(), ".history", "contexts", "ecommerce", "domains", "pricing", "discount~1", "3.md"))
+}
+
+func TestHistory_RevertedExternally(t *testing.T) {
+	// given
+	svc, _ := newIndexedService(t)
+	_, err := svc.CreateContext(&model.Context{URI: contextURI})
+	require.NoError(t, err)
+	_, err = svc.CreateDomain(&model.Domain{URI: domainURI})
+	require.NoError(t, err)
+	c, err := svc.CreateConcept(&model.Concept{URI: conceptURI, Name: "Discount"})
+	require.NoError(t, err)
+	path := filepath.Join(svc.RootDir(), "contexts/ecommerce/domains/pricing/discount.md")
+	first, err := os.ReadFile(path)
+	require.NoError(t, err)
+	c.Name = "Discounts"
+	_, err = svc.UpdateConcept(c)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, first, 0o600))
+	// when
+	c, err = svc.GetConcept(conceptURI)
+	require.NoError(t, err)
+	c.Name = "Rebates"
+	c.Body = "Rebates are paid back later.\n"
+	_, err = svc.UpdateConcept(c)
+	// then
+	require.NoError(t, err)
+
+	history, err := svc.History(conceptURI)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.True(t, history[1].Current)
+
+	diff, err := svc.Diff(conceptURI, 1, 2)
+	require.NoError(t, err)
+	assert.Contains(t, diff.Fields, service.FieldChange{Field: "name", From: "Discount", To: "Rebates"})
+
+	hits, err := svc.SearchText(service.TextSearchQuery{Query: "paid"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{conceptURI}, textHitURIs(hits))
+}
+
+func TestHistory_WrittenBeforeHistory(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	createConcept(t, svc)
+	require.NoError(t, os.RemoveAll(filepath.Join(svc.RootDir(), ".history")))
+	// when
+	history, err := svc.History(conceptURI)
+	// then
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, 1, history[0].Version)
+	assert.True(t, history[0].Current)
+
+	_, err = svc.Diff(conceptURI, 1, 1)
+	assert.NoError(t, err)
+}
+
+func TestHistory_Unknown(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	// when
+	_, err := svc.History(conceptURI)
+	// then
+	requireAppError(t, err, outputs.ErrNotFound)
+}
+
+func TestDiff(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	editConcept(t, svc)
+	// when
+	diff, err := svc.Diff(conceptURI, 1, 3)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, conceptURI, diff.URI)
+	assert.Equal(t, 1, diff.From)
+	assert.Equal(t, 3, diff.To)
+	assert.Equal(t, service.TagsDiff{Added: []string{discountTag}, Removed: []string{pricingTag}}, diff.Tags)
+	assert.Equal(t, service.RelationsDiff{
+		Added:   []model.RelationRef{{Type: dependsRelation, Target: domainURI}},
+		Removed: []model.RelationRef{},
+	}, diff.Relations)
+	assert.Equal(t, service.SourcesDiff{
+		Added:   []model.Source{{Type: "doc", Href: "b.md"}},
+		Removed: []model.Source{{Type: "doc", Href: "a.md"}},
+	}, diff.Sources)
+	assert.Contains(t, diff.Fields, service.FieldChange{Field: "name", From: "Discount", To: "Discounts"})
+	assert.Contains(t, diff.Fields, service.FieldChange{Field: "version", From: "1", To: "3"})
+	assert.Equal(t, "--- "+conceptURI+" v1\n"+
+		"+++ "+conceptURI+" v3\n"+
+		"@@ -1,5 +1,5 @@\n"+
+		" # Discount\n"+
+		" \n"+
+		" One line.\n"+
+		"-Two line.\n"+
+		"+Two lines.\n"+
+		" Three line.\n", diff.Body)
+}
+
+func TestDiff_Reversed(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	editConcept(t, svc)
+	// when
+	diff, err := svc.Diff(conceptURI, 2, 3)
+	reversed, reversedErr := svc.Diff(conceptURI, 3, 2)
+	// then
+	require.NoError(t, err)
+	require.NoError(t, reversedErr)
+	assert.Equal(t, diff.Tags.Added, reversed.Tags.Removed)
+	assert.Equal(t, diff.Relations.Added, reversed.Relations.Removed)
+
+	unchanged, err := svc.Diff(conceptURI, 3, 3)
+	require.NoError(t, err)
+	assert.Empty(t, unchanged.Fields)
+	assert.Empty(t, unchanged.Body)
+	assert.Empty(t, unchanged.Tags.Added)
+}
+
+func TestDiff_UnknownVersion(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	editConcept(t, svc)
+	// when
+	_, err := svc.Diff(conceptURI, 1, 4)
+	// then
+	appErr := requireAppError(t, err, outputs.ErrNotFound)
+	assert.Equal(t, []int{1, 2, 3}, appErr.Details["available_versions"])
+}
+
+func TestUnifiedDiff_Hunks(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	c := createConcept(t, svc)
+	c.Body = "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\n"
+	c, err := svc.UpdateConcept(c)
+	require.NoError(t, err)
+	c.Body = "A\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\n"
+	_, err = svc.UpdateConcept(c)
+	require.NoError(t, err)
+	// when
+	diff, err := svc.Diff(conceptURI, 2, 3)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "--- "+conceptURI+" v2\n"+
+		"+++ "+conceptURI+" v3\n"+
+		"@@ -1,4 +1,4 @@\n"+
+		"-a\n"+
+		"+A\n"+
+		" b\n"+
+		" c\n"+
+		" d\n"+
+		"@@ -9,4 +9,3 @@\n"+
+		" i\n"+
+		" j\n"+
+		" k\n"+
+		"-l\n", diff.Body)
+}
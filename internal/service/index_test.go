@@ -0,0 +1,177 @@
+package service_test
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/index"
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+)
+
+// newIndexedService returns a service over an empty store that keeps the
+// returned index in sync.
+func newIndexedService(t *testing.T) (*service.Service, *index.Index) {
+	t.Helper()
+
+	root := t.TempDir()
+	require.NoError(t, storage.InitRootDirs(root))
+
+	idx, err := index.Open(root)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = idx.Close() })
+
+	return service.New(root, service.WithIndex(idx)), idx
+}
+
+// failIndexing makes every later attempt to index the entity at rawURI in
+// the index of the store at root fail, so that writing it fails after its
+// file was replaced.
+func failIndexing(t *testing.T, root, rawURI string) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", filepath.Join(root, index.FileName))
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TRIGGER fail_indexing BEFORE INSERT ON files WHEN NEW.uri = '` + rawURI + `'
+		BEGIN SELECT RAISE(ABORT, 'indexing failed'); END`)
+	require.NoError(t, err)
+}
+
+func TestIndexedService_TracksWrites(t *testing.T) {
+	// given
+	svc, idx := newIndexedService(t)
+	createTag(t, svc, &model.Tag{URI: pricingTag})
+	_, err := svc.CreateContext(&model.Context{URI: contextURI})
+	require.NoError(t, err)
+	_, err = svc.CreateDomain(&model.Domain{URI: domainURI})
+	require.NoError(t, err)
+	// when
+	_, err = svc.CreateConcept(&model.Concept{URI: conceptURI, Tags: []string{pricingTag}})
+	// then
+	require.NoError(t, err)
+
+	tagged, err := idx.Tagged(pricingTag)
+	require.NoError(t, err)
+	assert.Equal(t, []string{conceptURI}, tagged)
+
+	requireAppError(t, svc.DeleteTag(pricingTag), outputs.ErrTagInUse)
+
+	// when — the whole context goes
+	_, err = svc.DeleteContext(contextURI, true)
+	// then
+	require.NoError(t, err)
+
+	uris, err := idx.URIs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{pricingTag}, uris)
+	require.NoError(t, svc.DeleteTag(pricingTag))
+}
+
+func TestIndexedService_BatchCommitIsIndexed(t *testing.T) {
+	// given
+	svc, idx := newIndexedService(t)
+	ops := []service.BatchOperation{
+		{Op: service.BatchCreate, URI: conceptURI, Entity: &model.Concept{
+			URI:       conceptURI,
+			Relations: []model.RelationRef{{Type: relatedRelation, Target: domainURI}},
+		}},
+		{Op: service.BatchCreate, URI: relatedRelation, Entity: &model.RelationType{URI: relatedRelation}},
+		{Op: service.BatchCreate, URI: domainURI, Entity: &model.Domain{URI: domainURI}},
+		{Op: service.BatchCreate, URI: contextURI, Entity: &model.Context{URI: contextURI}},
+	}
+	// when
+	_, err := svc.Batch(ops)
+	// then
+	require.NoError(t, err)
+
+	incoming, err := idx.Incoming(domainURI)
+	require.NoError(t, err)
+	assert.Equal(t, []index.Edge{{Source: conceptURI, Type: relatedRelation, Target: domainURI}}, incoming)
+	requireAppError(t, svc.DeleteRelationType(relatedRelation), outputs.ErrRelationInUse)
+}
+
+func TestSyncIndex_ExternalEdit(t *testing.T) {
+	// given
+	svc, idx := newIndexedService(t)
+	createTag(t, svc, &model.Tag{URI: pricingTag})
+	_, err := svc.CreateContext(&model.Context{URI: contextURI})
+	require.NoError(t, err)
+	_, err = svc.CreateDomain(&model.Domain{URI: domainURI})
+	require.NoError(t, err)
+	_, err = svc.CreateConcept(&model.Concept{URI: conceptURI, Name: "Discount"})
+	require.NoError(t, err)
+
+	unchanged, err := svc.SyncIndex()
+	require.NoError(t, err)
+	require.Empty(t, unchanged)
+
+	content, err := svc.Markdown(conceptURI)
+	require.NoError(t, err)
+	edited := strings.Replace(content, "tags: []", "tags:\n    - "+pricingTag, 1)
+	require.NotEqual(t, content, edited)
+	require.NoError(t, os.WriteFile(filepath.Join(svc.RootDir(), "contexts/ecommerce/domains/pricing/discount.md"), []byte(edited+"\n"), 0o600))
+	// when
+	changed, err := svc.SyncIndex()
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{conceptURI}, changed)
+
+	tagged, err := idx.Tagged(pricingTag)
+	require.NoError(t, err)
+	assert.Equal(t, []string{conceptURI}, tagged)
+}
+
+func TestSyncIndex_OtherService(t *testing.T) {
+	// given
+	svc, _ := newIndexedService(t)
+	_, err := svc.CreateContext(&model.Context{URI: contextURI})
+	require.NoError(t, err)
+
+	idx, err := index.Open(svc.RootDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = idx.Close() })
+	other := service.New(svc.RootDir(), service.WithIndex(idx))
+
+	_, err = svc.SyncIndex()
+	require.NoError(t, err)
+	// when — another server shares the index it updates
+	_, err = other.CreateDomain(&model.Domain{URI: domainURI})
+	require.NoError(t, err)
+	changed, err := svc.SyncIndex()
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{domainURI}, changed)
+
+	// when — the service writes itself
+	_, err = svc.DeleteDomain(domainURI, true)
+	require.NoError(t, err)
+	changed, err = svc.SyncIndex()
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{domainURI}, changed)
+}
+
+func TestSyncIndex_WithoutIndex(t *testing.T) {
+	// given
+	svc := newService(t)
+	// when
+	unchanged, err := svc.SyncIndex()
+	require.NoError(t, err)
+	_, err = svc.CreateContext(&model.Context{URI: contextURI})
+	require.NoError(t, err)
+	changed, err := svc.SyncIndex()
+	// then
+	assert.NoError(t, err)
+	assert.Empty(t, unchanged)
+	assert.Equal(t, []string{contextURI}, changed)
+}
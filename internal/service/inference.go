@@ -0,0 +1,254 @@
+package service
+
+import (
+	"slices"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+)
+
+// edgeKey identifies an edge regardless of how it was derived.
+type edgeKey struct {
+	source, relation, target string
+}
+
+func keyOf(e GraphEdge) edgeKey {
+	return edgeKey{e.Source, e.Type, e.Target}
+}
+
+// reasoner is a relationGraph that adds the edges implied by the relation
+// type flags to the asserted ones:
+//
+//   - y -T-> x for every x -T-> y when T is symmetric;
+//   - y -I-> x for every x -T-> y when T declares InverseOf I;
+//   - x -T-> z whenever x -T-> ... -T-> z and T is transitive.
+//
+// Inferred edges carry the asserted edges that justify them, in path order.
+type reasoner struct {
+	base  relationGraph
+	types map[string]*model.RelationType
+	// transitive lists the transitive relation types in URI order.
+	transitive []string
+	outCache   map[string][]GraphEdge
+	inCache    map[string][]GraphEdge
+}
+
+func newReasoner(base relationGraph, types []*model.RelationType) *reasoner {
+	r := &reasoner{
+		base:     base,
+		types:    make(map[string]*model.RelationType, len(types)),
+		outCache: make(map[string][]GraphEdge),
+		inCache:  make(map[string][]GraphEdge),
+	}
+
+	for _, t := range types {
+		r.types[t.URI] = t
+
+		if t.Transitive {
+			r.transitive = append(r.transitive, t.URI)
+		}
+	}
+
+	slices.Sort(r.transitive)
+	return r
+}
+
+func (r *reasoner) outgoing(raw string) ([]GraphEdge, error) {
+	direct, err := r.directOutgoing(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.close(raw, direct, r.directOutgoing, func(e GraphEdge) string { return e.Target }, func(start, end, relation string) GraphEdge {
+		return GraphEdge{Source: start, Type: relation, Target: end}
+	})
+}
+
+func (r *reasoner) incoming(raw string) ([]GraphEdge, error) {
+	direct, err := r.directIncoming(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.close(raw, direct, r.directIncoming, func(e GraphEdge) string { return e.Source }, func(start, end, relation string) GraphEdge {
+		return GraphEdge{Source: end, Type: relation, Target: start}
+	})
+}
+
+// directOutgoing returns the asserted edges leaving raw plus the edges
+// leaving raw implied by a single asserted edge pointing at it.
+func (r *reasoner) directOutgoing(raw string) ([]GraphEdge, error) {
+	if cached, ok := r.outCache[raw]; ok {
+		return cached, nil
+	}
+
+	asserted, err := r.base.outgoing(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	pointing, err := r.base.incoming(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := r.merge(asserted, r.reversed(pointing))
+	r.outCache[raw] = edges
+	return edges, nil
+}
+
+// directIncoming returns the asserted edges pointing at raw plus the edges
+// pointing at raw implied by a single asserted edge leaving it.
+func (r *reasoner) directIncoming(raw string) ([]GraphEdge, error) {
+	if cached, ok := r.inCache[raw]; ok {
+		return cached, nil
+	}
+
+	asserted, err := r.base.incoming(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	leaving, err := r.base.outgoing(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := r.merge(asserted, r.reversed(leaving))
+	r.inCache[raw] = edges
+	return edges, nil
+}
+
+// reversed returns the edges implied in the opposite direction by the
+// symmetric and inverse relation types of asserted.
+func (r *reasoner) reversed(asserted []GraphEdge) []GraphEdge {
+	var implied []GraphEdge
+
+	for _, e := range asserted {
+		t := r.types[e.Type]
+		if t == nil || e.Source == e.Target {
+			continue
+		}
+
+		justification := justificationOf(e)
+
+		if t.Symmetric {
+			implied = append(implied, GraphEdge{Source: e.Target, Type: e.Type, Target: e.Source, Inferred: true, Justification: justification})
+		}
+
+		if t.InverseOf != "" {
+			implied = append(implied, GraphEdge{Source: e.Target, Type: t.InverseOf, Target: e.Source, Inferred: true, Justification: justification})
+		}
+	}
+
+	return implied
+}
+
+// close adds to the direct edges of start the transitive edges reachable
+// through direct edges of the same transitive type. next returns the direct
+// edges of a node in the walking direction, far the node an edge leads to,
+// and edge builds the inferred edge from start to a reached node.
+func (r *reasoner) close(
+	start string,
+	direct []GraphEdge,
+	next func(raw string) ([]GraphEdge, error),
+	far func(e GraphEdge) string,
+	edge func(start, end, relation string) GraphEdge,
+) ([]GraphEdge, error) {
+	var closure []GraphEdge
+
+	for _, relation := range r.transitive {
+		visited := map[string]bool{start: true}
+
+		type step struct {
+			node          string
+			justification []AssertedEdge
+		}
+
+		var frontier []step
+
+		for _, e := range direct {
+			if e.Type == relation && !visited[far(e)] {
+				visited[far(e)] = true
+				frontier = append(frontier, step{node: far(e), justification: justificationOf(e)})
+			}
+		}
+
+		for len(frontier) > 0 {
+			var following []step
+
+			for _, s := range frontier {
+				edges, err := next(s.node)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, e := range edges {
+					end := far(e)
+					if e.Type != relation || visited[end] {
+						continue
+					}
+
+					visited[end] = true
+					justification := slices.Concat(s.justification, justificationOf(e))
+
+					inferred := edge(start, end, relation)
+					inferred.Inferred = true
+					inferred.Justification = justification
+					closure = append(closure, inferred)
+
+					following = append(following, step{node: end, justification: justification})
+				}
+			}
+
+			frontier = following
+		}
+	}
+
+	return r.merge(direct, closure), nil
+}
+
+// merge appends to edges the implied edges that are not already present.
+func (r *reasoner) merge(edges, implied []GraphEdge) []GraphEdge {
+	seen := make(map[edgeKey]bool, len(edges)+len(implied))
+	merged := make([]GraphEdge, 0, len(edges)+len(implied))
+
+	for _, e := range slices.Concat(edges, implied) {
+		if !seen[keyOf(e)] {
+			seen[keyOf(e)] = true
+			merged = append(merged, e)
+		}
+	}
+
+	return merged
+}
+
+// justificationOf returns the asserted edges e stands for.
+func justificationOf(e GraphEdge) []AssertedEdge {
+	if e.Inferred {
+		return e.Justification
+	}
+
+	return []AssertedEdge{{Source: e.Source, Type: e.Type, Target: e.Target}}
+}
+
+// newRelationReasoner returns a reasoner over the relations of the store
+// that knows every stored relation type.
+func (s *Service) newRelationReasoner(base relationGraph) (*reasoner, error) {
+	uris, err := s.listURIs(model.EntityTypeRelation)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make([]*model.RelationType, 0, len(uris))
+
+	for _, u := range uris {
+		t, err := s.loadRelationType(u)
+		if err != nil {
+			return nil, err
+		}
+
+		types = append(types, t)
+	}
+
+	return newReasoner(base, types), nil
+}
@@ -0,0 +1,142 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+// populateInference stores
+//
+//	discount -part-of-> coupon -part-of-> voucher
+//	campaign -related-to-> discount
+//
+// where part-of is transitive with inverse has-part, and related-to is
+// symmetric.
+func populateInference(t *testing.T, svc *service.Service) {
+	t.Helper()
+
+	related := func(rel, target string) []model.RelationRef {
+		return []model.RelationRef{{Type: rel, Target: target}}
+	}
+
+	_, err := svc.Batch([]service.BatchOperation{
+		{Op: service.BatchCreate, URI: partOfRelation, Entity: &model.RelationType{URI: partOfRelation, Transitive: true}},
+		{Op: service.BatchCreate, URI: hasPartRelation, Entity: &model.RelationType{URI: hasPartRelation, Transitive: true, InverseOf: partOfRelation}},
+		{Op: service.BatchCreate, URI: relatedRelation, Entity: &model.RelationType{URI: relatedRelation, Symmetric: true}},
+		{Op: service.BatchCreate, URI: contextURI, Entity: &model.Context{URI: contextURI}},
+		{Op: service.BatchCreate, URI: domainURI, Entity: &model.Domain{URI: domainURI}},
+		{Op: service.BatchCreate, URI: conceptURI, Entity: &model.Concept{URI: conceptURI, Relations: related(partOfRelation, otherConceptURI)}},
+		{Op: service.BatchCreate, URI: otherConceptURI, Entity: &model.Concept{URI: otherConceptURI, Relations: related(partOfRelation, voucherURI)}},
+		{Op: service.BatchCreate, URI: voucherURI, Entity: &model.Concept{URI: voucherURI}},
+		{Op: service.BatchCreate, URI: campaignURI, Entity: &model.Concept{URI: campaignURI, Relations: related(relatedRelation, conceptURI)}},
+	})
+	require.NoError(t, err)
+}
+
+func outgoing(t *testing.T, svc *service.Service, rawURI string, infer bool) []service.GraphEdge {
+	t.Helper()
+
+	g, err := svc.Graph(service.GraphQuery{URI: rawURI, Direction: service.DirectionOutgoing, Infer: infer})
+	require.NoError(t, err)
+
+	var edges []service.GraphEdge
+
+	for _, e := range g.Edges {
+		if e.Source == rawURI {
+			edges = append(edges, e)
+		}
+	}
+
+	return edges
+}
+
+func TestInference_SymmetricAndTransitive(t *testing.T) {
+	// given
+	svc, _ := newIndexedService(t)
+	populateInference(t, svc)
+	discountPartOfCoupon := service.AssertedEdge{Source: conceptURI, Type: partOfRelation, Target: otherConceptURI}
+	couponPartOfVoucher := service.AssertedEdge{Source: otherConceptURI, Type: partOfRelation, Target: voucherURI}
+	campaignRelatedToDiscount := service.AssertedEdge{Source: campaignURI, Type: relatedRelation, Target: conceptURI}
+	// when
+	edges := outgoing(t, svc, conceptURI, true)
+	// then
+	assert.Equal(t, []service.GraphEdge{
+		{Source: conceptURI, Type: partOfRelation, Target: otherConceptURI},
+		{
+			Source:        conceptURI,
+			Type:          relatedRelation,
+			Target:        campaignURI,
+			Inferred:      true,
+			Justification: []service.AssertedEdge{campaignRelatedToDiscount},
+		},
+		{
+			Source:        conceptURI,
+			Type:          partOfRelation,
+			Target:        voucherURI,
+			Inferred:      true,
+			Justification: []service.AssertedEdge{discountPartOfCoupon, couponPartOfVoucher},
+		},
+	}, edges)
+}
+
+func TestInference_InverseAndTransitive(t *testing.T) {
+	// given
+	svc := newService(t)
+	populateInference(t, svc)
+	discountPartOfCoupon := service.AssertedEdge{Source: conceptURI, Type: partOfRelation, Target: otherConceptURI}
+	couponPartOfVoucher := service.AssertedEdge{Source: otherConceptURI, Type: partOfRelation, Target: voucherURI}
+	// when
+	edges := outgoing(t, svc, voucherURI, true)
+	// then
+	assert.Equal(t, []service.GraphEdge{
+		{
+			Source:        voucherURI,
+			Type:          hasPartRelation,
+			Target:        otherConceptURI,
+			Inferred:      true,
+			Justification: []service.AssertedEdge{couponPartOfVoucher},
+		},
+		{
+			Source:        voucherURI,
+			Type:          hasPartRelation,
+			Target:        conceptURI,
+			Inferred:      true,
+			Justification: []service.AssertedEdge{couponPartOfVoucher, discountPartOfCoupon},
+		},
+	}, edges)
+}
+
+func TestInference_Incoming(t *testing.T) {
+	// given
+	svc, _ := newIndexedService(t)
+	populateInference(t, svc)
+	// when
+	g, err := svc.Graph(service.GraphQuery{URI: voucherURI, Direction: service.DirectionIncoming, Infer: true})
+	// then
+	require.NoError(t, err)
+
+	var sources []string
+
+	for _, e := range g.Edges {
+		if e.Type == partOfRelation {
+			sources = append(sources, e.Source)
+		}
+	}
+
+	assert.Equal(t, []string{otherConceptURI, conceptURI}, sources)
+}
+
+func TestInference_Disabled(t *testing.T) {
+	// given
+	svc, _ := newIndexedService(t)
+	populateInference(t, svc)
+	// when
+	edges := outgoing(t, svc, voucherURI, false)
+	// then
+	assert.Empty(t, edges)
+}
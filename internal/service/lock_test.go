@@ -0,0 +1,125 @@
+package service_test
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+const (
+	updateHelperEnv = "KNOWLEDGE_MCP_UPDATE_HELPER_ROOT"
+	// conflictExitCode is the exit code of a helper process whose update
+	// was refused with a version conflict.
+	conflictExitCode = 3
+)
+
+// renameConcept updates the concept at conceptURI from version 1, as every
+// concurrent writer read it.
+func renameConcept(svc *service.Service, name string) error {
+	_, err := svc.UpdateConcept(&model.Concept{URI: conceptURI, Name: name, Version: 1})
+	return err
+}
+
+func isVersionConflict(err error) bool {
+	var appErr *outputs.AppError
+	return errors.As(err, &appErr) && appErr.ErrorCode == outputs.ErrVersionConflict
+}
+
+// TestConcurrentUpdates_HelperProcess is the body of the writer processes
+// started by TestConcurrentUpdates_Processes; it does nothing when run as a
+// test.
+func TestConcurrentUpdates_HelperProcess(t *testing.T) {
+	root := os.Getenv(updateHelperEnv)
+	if root == "" {
+		t.Skip("only runs as a helper process")
+	}
+
+	err := renameConcept(service.New(root), "Renamed")
+	if isVersionConflict(err) {
+		os.Exit(conflictExitCode)
+	}
+
+	require.NoError(t, err)
+}
+
+func TestConcurrentUpdates_Goroutines(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	createConcept(t, svc)
+	writers := 8
+
+	var wg sync.WaitGroup
+	var updated, conflicts atomic.Int32
+	// when — each writer has its own service, as separate servers would
+	for range writers {
+		wg.Go(func() {
+			err := renameConcept(service.New(svc.RootDir()), "Renamed")
+			switch {
+			case err == nil:
+				updated.Add(1)
+			case isVersionConflict(err):
+				conflicts.Add(1)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	wg.Wait()
+	// then
+	assert.Equal(t, int32(1), updated.Load())
+	assert.Equal(t, int32(writers-1), conflicts.Load())
+
+	c, err := svc.GetConcept(conceptURI)
+	require.NoError(t, err)
+	assert.Equal(t, 2, c.Version)
+}
+
+func TestConcurrentUpdates_Processes(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	createConcept(t, svc)
+	writers := 4
+	cmds := make([]*exec.Cmd, 0, writers)
+	// when
+	for range writers {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestConcurrentUpdates_HelperProcess$") //nolint:gosec
+		cmd.Env = append(os.Environ(), updateHelperEnv+"="+svc.RootDir())
+		require.NoError(t, cmd.Start())
+		cmds = append(cmds, cmd)
+	}
+
+	// then
+	updated, conflicts := 0, 0
+
+	for _, cmd := range cmds {
+		err := cmd.Wait()
+
+		var exitErr *exec.ExitError
+		switch {
+		case err == nil:
+			updated++
+		case errors.As(err, &exitErr) && exitErr.ExitCode() == conflictExitCode:
+			conflicts++
+		default:
+			require.NoError(t, err)
+		}
+	}
+
+	assert.Equal(t, 1, updated)
+	assert.Equal(t, writers-1, conflicts)
+
+	c, err := svc.GetConcept(conceptURI)
+	require.NoError(t, err)
+	assert.Equal(t, 2, c.Version)
+}
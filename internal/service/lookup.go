@@ -0,0 +1,23 @@
+package service
+
+import (
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// storeLookup exposes the stored entities to the validation package.
+type storeLookup struct {
+	s *Service
+}
+
+func (l storeLookup) Exists(u *uri.URI) bool {
+	return l.s.exists(u)
+}
+
+func (l storeLookup) Tag(u *uri.URI) (*model.Tag, error) {
+	return l.s.loadTag(u)
+}
+
+func (l storeLookup) RelationType(u *uri.URI) (*model.RelationType, error) {
+	return l.s.loadRelationType(u)
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// Body merge strategies.
+const (
+	MergeBodyConcat     = "concat"
+	MergeBodyKeepTarget = "keep_target"
+)
+
+var mergeBodyStrategies = []string{MergeBodyConcat, MergeBodyKeepTarget}
+
+// MergeRequest asks for the concept at Source to be merged into the concept
+// at Target. Both versions must match the versions on disk. Body selects how
+// the bodies are combined and defaults to MergeBodyConcat.
+type MergeRequest struct {
+	Source        string
+	SourceVersion int
+	Target        string
+	TargetVersion int
+	Body          string
+}
+
+// MergeResult reports the merged concept and every other entity whose
+// references were redirected from the source to the target. Files holds
+// every file written or removed, relative to the root directory.
+type MergeResult struct {
+	Concept *model.Concept  `json:"concept"`
+	Updated []UpdatedEntity `json:"updated"`
+	Files   []string        `json:"files"`
+}
+
+// MergeConcepts folds the source concept into the target concept: tags,
+// relations and sources are united without duplicates, bodies are combined
+// according to the strategy, every reference to the source is redirected to
+// the target and the source is deleted. The merge is refused when the result
+// would break a reference rule, such as a relation type that cannot be used
+// from the target's context, and is either applied entirely or not at all.
+// The source URI is kept as a redirect to the target.
+func (s *Service) MergeConcepts(r MergeRequest) (*MergeResult, error) {
+	source, err := parseURI(r.Source, model.EntityTypeConcept)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := parseURI(r.Target, model.EntityTypeConcept)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.Raw == target.Raw {
+		return nil, invalidArgumentError("target", "a concept cannot be merged into itself", map[string]any{"value": target.Raw})
+	}
+
+	strategy := r.Body
+	if strategy == "" {
+		strategy = MergeBodyConcat
+	}
+
+	if !slices.Contains(mergeBodyStrategies, strategy) {
+		return nil, invalidArgumentError("body", fmt.Sprintf("unknown body merge strategy %q", r.Body), map[string]any{
+			"value":   r.Body,
+			"allowed": mergeBodyStrategies,
+		})
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	from, err := s.loadConcept(source)
+	if err != nil {
+		return nil, err
+	}
+
+	if from.Version != r.SourceVersion {
+		return nil, versionConflictError(source.Raw, r.SourceVersion, from.Version)
+	}
+
+	into, err := s.loadConcept(target)
+	if err != nil {
+		return nil, err
+	}
+
+	if into.Version != r.TargetVersion {
+		return nil, versionConflictError(target.Raw, r.TargetVersion, into.Version)
+	}
+
+	overlay := newOverlayStore(s.files)
+	tx := s.transaction(overlay)
+
+	result, err := tx.applyMerge(from, into, strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.commit(overlay); err != nil {
+		return nil, err
+	}
+
+	redirect := model.Redirect{From: source.Raw, To: target.Raw, Reason: model.RedirectMerged, Created: s.clock()}
+
+	if err := s.recordRedirects([]model.Redirect{redirect}); err != nil {
+		return nil, fmt.Errorf("merged %s into %s but failed to record the redirect: %w", source, target, err)
+	}
+
+	return result, nil
+}
+
+func (s *Service) applyMerge(from, into *model.Concept, strategy string) (*MergeResult, error) {
+	source, err := uri.Parse(from.URI)
+	if err != nil {
+		return nil, invalidURIError(from.URI, err)
+	}
+
+	target, err := uri.Parse(into.URI)
+	if err != nil {
+		return nil, invalidURIError(into.URI, err)
+	}
+
+	now := s.clock()
+	renamed := map[string]string{source.Raw: target.Raw}
+
+	merged := mergeConcepts(from, into, strategy)
+	rewriteReferences(merged, renamed)
+	merged.Relations = slices.DeleteFunc(merged.Relations, func(ref model.RelationRef) bool { return ref.Target == target.Raw })
+	bumpVersion(merged, now)
+
+	if err := s.saveConcept(target, merged); err != nil {
+		return nil, err
+	}
+
+	if err := s.deleteFile(source); err != nil {
+		return nil, err
+	}
+
+	result := &MergeResult{
+		Concept: merged,
+		Updated: []UpdatedEntity{},
+		Files:   []string{s.relativeFileName(target), s.relativeFileName(source)},
+	}
+
+	updated, err := s.redirectReferences(renamed, map[string]bool{target.Raw: true}, now)
+	if err != nil {
+		return nil, err
+	}
+
+	written := []*uri.URI{target}
+
+	for _, u := range updated {
+		result.Updated = append(result.Updated, UpdatedEntity{URI: u.uri.Raw, Version: u.version})
+		result.Files = append(result.Files, s.relativeFileName(u.uri))
+		written = append(written, u.uri)
+	}
+
+	if err := s.checkAll(written); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// mergeConcepts returns into with the tags, relations and sources of from
+// added, and the bodies combined according to strategy.
+func mergeConcepts(from, into *model.Concept, strategy string) *model.Concept {
+	merged := *into
+	merged.Tags = uniqueStrings(slices.Concat(into.Tags, from.Tags))
+	merged.Relations = uniqueRelations(slices.Concat(into.Relations, from.Relations))
+	merged.Sources = uniqueSources(slices.Concat(into.Sources, from.Sources))
+
+	if strategy == MergeBodyConcat {
+		merged.Body = concatBodies(into.Body, from.Body, from.URI)
+	}
+
+	return &merged
+}
+
+// concatBodies appends the source body to the target body, separated by a
+// comment naming the merged concept.
+func concatBodies(target, source, sourceURI string) string {
+	if strings.TrimSpace(source) == "" {
+		return target
+	}
+
+	if strings.TrimSpace(target) == "" {
+		return source
+	}
+
+	return fmt.Sprintf("%s\n\n<!-- merged from %s -->\n\n%s", strings.TrimRight(target, "\n"), sourceURI, source)
+}
+
+// uniqueSources returns sources without duplicates, keeping the first
+// occurrence of each.
+func uniqueSources(sources []model.Source) []model.Source {
+	unique := make([]model.Source, 0, len(sources))
+
+	for _, src := range sources {
+		if !slices.Contains(unique, src) {
+			unique = append(unique, src)
+		}
+	}
+
+	return unique
+}
@@ -0,0 +1,190 @@
+package service_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+// populateMerge stores coupon and voucher as two takes on the same concept,
+// with discount depending on both.
+func populateMerge(t *testing.T, svc *service.Service) {
+	t.Helper()
+
+	_, err := svc.Batch([]service.BatchOperation{
+		{Op: service.BatchCreate, URI: pricingTag, Entity: &model.Tag{URI: pricingTag}},
+		{Op: service.BatchCreate, URI: discountTag, Entity: &model.Tag{URI: discountTag}},
+		{Op: service.BatchCreate, URI: partOfRelation, Entity: &model.RelationType{URI: partOfRelation}},
+		{Op: service.BatchCreate, URI: dependsRelation, Entity: &model.RelationType{URI: dependsRelation}},
+		{Op: service.BatchCreate, URI: contextURI, Entity: &model.Context{URI: contextURI}},
+		{Op: service.BatchCreate, URI: domainURI, Entity: &model.Domain{URI: domainURI}},
+		{Op: service.BatchCreate, URI: otherConceptURI, Entity: &model.Concept{
+			URI:       otherConceptURI,
+			Name:      "Coupon",
+			Tags:      []string{pricingTag, discountTag},
+			Relations: []model.RelationRef{{Type: dependsRelation, Target: voucherURI}, {Type: partOfRelation, Target: domainURI}},
+			Sources:   []model.Source{{Type: "doc", Href: "a.md"}},
+			Body:      "Coupon body\n",
+		}},
+		{Op: service.BatchCreate, URI: voucherURI, Entity: &model.Concept{
+			URI:       voucherURI,
+			Name:      "Voucher",
+			Tags:      []string{pricingTag},
+			Relations: []model.RelationRef{{Type: partOfRelation, Target: domainURI}},
+			Sources:   []model.Source{{Type: "doc", Href: "a.md"}, {Type: "doc", Href: "b.md"}},
+			Body:      "Voucher body\n",
+		}},
+		{Op: service.BatchCreate, URI: conceptURI, Entity: &model.Concept{
+			URI:       conceptURI,
+			Name:      "Discount",
+			Relations: []model.RelationRef{{Type: dependsRelation, Target: otherConceptURI}, {Type: dependsRelation, Target: voucherURI}},
+		}},
+	})
+	require.NoError(t, err)
+}
+
+func TestMergeConcepts(t *testing.T) {
+	// given
+	svc := newService(t)
+	populateMerge(t, svc)
+	// when
+	result, err := svc.MergeConcepts(service.MergeRequest{
+		Source:        otherConceptURI,
+		SourceVersion: 1,
+		Target:        voucherURI,
+		TargetVersion: 1,
+	})
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "Voucher", result.Concept.Name)
+	assert.Equal(t, 2, result.Concept.Version)
+	assert.Equal(t, []string{pricingTag, discountTag}, result.Concept.Tags)
+	assert.Equal(t, []model.RelationRef{{Type: partOfRelation, Target: domainURI}}, result.Concept.Relations)
+	assert.Equal(t, []model.Source{{Type: "doc", Href: "a.md"}, {Type: "doc", Href: "b.md"}}, result.Concept.Sources)
+	assert.Equal(t, "Voucher body\n\n<!-- merged from "+otherConceptURI+" -->\n\nCoupon body\n", result.Concept.Body)
+	assert.Equal(t, []service.UpdatedEntity{{URI: conceptURI, Version: 2}}, result.Updated)
+	assert.Equal(t, []string{
+		"contexts/ecommerce/domains/pricing/voucher.md",
+		"contexts/ecommerce/domains/pricing/coupon.md",
+		"contexts/ecommerce/domains/pricing/discount.md",
+	}, result.Files)
+
+	assert.NoFileExists(t, filepath.Join(svc.RootDir(), "contexts/ecommerce/domains/pricing/coupon.md"))
+
+	stored, err := svc.GetConcept(voucherURI)
+	require.NoError(t, err)
+	assert.Equal(t, result.Concept, stored)
+
+	discount, err := svc.GetConcept(conceptURI)
+	require.NoError(t, err)
+	assert.Equal(t, []model.RelationRef{{Type: dependsRelation, Target: voucherURI}}, discount.Relations)
+}
+
+func TestMergeConcepts_KeepTargetBody(t *testing.T) {
+	// given
+	svc, _ := newIndexedService(t)
+	populateMerge(t, svc)
+	// when
+	result, err := svc.MergeConcepts(service.MergeRequest{
+		Source:        otherConceptURI,
+		SourceVersion: 1,
+		Target:        voucherURI,
+		TargetVersion: 1,
+		Body:          service.MergeBodyKeepTarget,
+	})
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "Voucher body\n", result.Concept.Body)
+
+	backlinks, err := svc.Backlinks(voucherURI)
+	require.NoError(t, err)
+	assert.Equal(t, 1, backlinks.Total)
+}
+
+func TestMergeConcepts_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		request service.MergeRequest
+		code    string
+	}{
+		{
+			name:    "into itself",
+			request: service.MergeRequest{Source: voucherURI, SourceVersion: 1, Target: voucherURI, TargetVersion: 1},
+			code:    outputs.ErrValidationFailed,
+		},
+		{
+			name:    "not a concept",
+			request: service.MergeRequest{Source: domainURI, SourceVersion: 1, Target: voucherURI, TargetVersion: 1},
+			code:    outputs.ErrTypeMismatch,
+		},
+		{
+			name:    "unknown body strategy",
+			request: service.MergeRequest{Source: otherConceptURI, SourceVersion: 1, Target: voucherURI, TargetVersion: 1, Body: "interleave"},
+			code:    outputs.ErrValidationFailed,
+		},
+		{
+			name:    "missing source",
+			request: service.MergeRequest{Source: campaignURI, SourceVersion: 1, Target: voucherURI, TargetVersion: 1},
+			code:    outputs.ErrNotFound,
+		},
+		{
+			name:    "stale source",
+			request: service.MergeRequest{Source: otherConceptURI, SourceVersion: 2, Target: voucherURI, TargetVersion: 1},
+			code:    outputs.ErrVersionConflict,
+		},
+		{
+			name:    "stale target",
+			request: service.MergeRequest{Source: otherConceptURI, SourceVersion: 1, Target: voucherURI, TargetVersion: 2},
+			code:    outputs.ErrVersionConflict,
+		},
+	}
+
+	svc := newService(t)
+	populateMerge(t, svc)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.MergeConcepts(tc.request)
+			requireAppError(t, err, tc.code)
+		})
+	}
+}
+
+func TestMergeConcepts_RelationScope(t *testing.T) {
+	// given
+	svc := newService(t)
+	populateMerge(t, svc)
+	localRelation := logisticsURI + "/relations/ships-with"
+	_, err := svc.Batch([]service.BatchOperation{
+		{Op: service.BatchCreate, URI: logisticsURI, Entity: &model.Context{URI: logisticsURI}},
+		{Op: service.BatchCreate, URI: shippingURI, Entity: &model.Domain{URI: shippingURI}},
+		{Op: service.BatchCreate, URI: localRelation, Entity: &model.RelationType{URI: localRelation}},
+		{Op: service.BatchCreate, URI: freeShippingURI, Entity: &model.Concept{
+			URI:       freeShippingURI,
+			Relations: []model.RelationRef{{Type: localRelation, Target: shippingURI}},
+		}},
+	})
+	require.NoError(t, err)
+	// when
+	_, err = svc.MergeConcepts(service.MergeRequest{
+		Source:        freeShippingURI,
+		SourceVersion: 1,
+		Target:        voucherURI,
+		TargetVersion: 1,
+	})
+	// then
+	requireAppError(t, err, outputs.ErrScopeViolation)
+
+	_, err = svc.GetConcept(freeShippingURI)
+	require.NoError(t, err)
+
+	voucher, err := svc.GetConcept(voucherURI)
+	require.NoError(t, err)
+	assert.Equal(t, 1, voucher.Version)
+}
@@ -0,0 +1,370 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// MoveResult reports everything a move changed. Moved lists the entity and,
+// for contexts and domains, everything stored inside it; Updated lists the
+// other entities whose references were rewritten. Files holds every file
+// written or removed, relative to the root directory.
+type MoveResult struct {
+	From    string          `json:"from"`
+	To      string          `json:"to"`
+	Moved   []MovedEntity   `json:"moved"`
+	Updated []UpdatedEntity `json:"updated"`
+	Files   []string        `json:"files"`
+}
+
+// MovedEntity is an entity stored under a new URI by a move.
+type MovedEntity struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Version int    `json:"version"`
+}
+
+// UpdatedEntity is an entity rewritten to follow a move.
+type UpdatedEntity struct {
+	URI     string `json:"uri"`
+	Version int    `json:"version"`
+}
+
+type relocation struct {
+	from *uri.URI
+	to   *uri.URI
+}
+
+// Move renames the entity at fromRaw to toRaw, provided version still matches
+// the version on disk. Both URIs must identify the same entity type, so a
+// concept can be renamed or moved to another domain, a domain to another
+// context, and so on. Contexts and domains are moved with everything inside
+// them. Every tag, relation, broader/narrower and inverse-of reference to a
+// moved entity is rewritten, and every entity written gets a new version.
+// The move is checked as a whole and either applied entirely or not at all.
+// The old URIs are kept as redirects to the new ones.
+func (s *Service) Move(fromRaw, toRaw string, version int) (*MoveResult, error) {
+	from, err := uri.Parse(fromRaw)
+	if err != nil {
+		return nil, invalidURIError(fromRaw, err)
+	}
+
+	to, err := parseURI(toRaw, from.Entity)
+	if err != nil {
+		return nil, err
+	}
+
+	if to.Raw == from.Raw {
+		return nil, invalidArgumentError("to", "the new URI is the same as the current one", map[string]any{"value": to.Raw})
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	current, err := s.loadEntity(from)
+	if err != nil {
+		return nil, err
+	}
+
+	if v := entityVersion(current); v != version {
+		return nil, versionConflictError(from.Raw, version, v)
+	}
+
+	moves, err := s.relocations(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range moves {
+		if s.exists(m.to) {
+			return nil, alreadyExistsError(m.to.Raw)
+		}
+	}
+
+	overlay := newOverlayStore(s.files)
+	tx := s.transaction(overlay)
+
+	result, err := tx.applyMove(moves)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.commit(overlay); err != nil {
+		return nil, err
+	}
+
+	redirects := make([]model.Redirect, 0, len(moves))
+
+	for _, m := range moves {
+		redirects = append(redirects, model.Redirect{From: m.from.Raw, To: m.to.Raw, Reason: model.RedirectMoved, Created: s.clock()})
+	}
+
+	if err := s.recordRedirects(redirects); err != nil {
+		return nil, fmt.Errorf("moved %s to %s but failed to record the redirect: %w", from, to, err)
+	}
+
+	return result, nil
+}
+
+// relocations pairs from and, for contexts and domains, every entity inside
+// it with the URI it gets under to.
+func (s *Service) relocations(from, to *uri.URI) ([]relocation, error) {
+	moves := []relocation{{from: from, to: to}}
+
+	if from.Entity != model.EntityTypeContext && from.Entity != model.EntityTypeDomain {
+		return moves, nil
+	}
+
+	contained, err := s.containedEntities(from)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, raw := range contained {
+		cu, err := uri.Parse(raw)
+		if err != nil {
+			return nil, invalidURIError(raw, err)
+		}
+
+		target := to.Raw + strings.TrimPrefix(raw, from.Raw)
+
+		tu, err := uri.Parse(target)
+		if err != nil {
+			return nil, invalidURIError(target, err)
+		}
+
+		moves = append(moves, relocation{from: cu, to: tu})
+	}
+
+	return moves, nil
+}
+
+// applyMove writes the moved entities under their new URIs, removes the old
+// ones and rewrites every reference to them, then checks everything written
+// against the final state.
+func (s *Service) applyMove(moves []relocation) (*MoveResult, error) {
+	now := s.clock()
+	renamed := make(map[string]string, len(moves))
+	relocated := make(map[string]bool, len(moves))
+
+	for _, m := range moves {
+		renamed[m.from.Raw] = m.to.Raw
+		relocated[m.to.Raw] = true
+	}
+
+	result := &MoveResult{
+		From:    moves[0].from.Raw,
+		To:      moves[0].to.Raw,
+		Moved:   []MovedEntity{},
+		Updated: []UpdatedEntity{},
+		Files:   []string{},
+	}
+
+	var written []*uri.URI
+
+	for _, m := range moves {
+		e, err := s.loadEntity(m.from)
+		if err != nil {
+			return nil, err
+		}
+
+		setEntityURI(e, m.to.Raw)
+		rewriteReferences(e, renamed)
+		bumpVersion(e, now)
+
+		if err := s.saveEntity(m.to, e); err != nil {
+			return nil, err
+		}
+
+		written = append(written, m.to)
+		result.Moved = append(result.Moved, MovedEntity{From: m.from.Raw, To: m.to.Raw, Version: entityVersion(e)})
+		result.Files = append(result.Files, s.relativeFileName(m.from), s.relativeFileName(m.to))
+	}
+
+	if err := s.deleteFile(moves[0].from); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.redirectReferences(renamed, relocated, now)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, u := range updated {
+		result.Updated = append(result.Updated, UpdatedEntity{URI: u.uri.Raw, Version: u.version})
+		result.Files = append(result.Files, s.relativeFileName(u.uri))
+		written = append(written, u.uri)
+	}
+
+	if err := s.checkAll(written); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+type redirected struct {
+	uri     *uri.URI
+	version int
+}
+
+// redirectReferences rewrites the references to every key of renamed held by
+// the stored entities not in skip, saving each changed entity with a new
+// version.
+func (s *Service) redirectReferences(renamed map[string]string, skip map[string]bool, now time.Time) ([]redirected, error) {
+	all, err := s.listURIs()
+	if err != nil {
+		return nil, err
+	}
+
+	var updated []redirected
+
+	for _, u := range all {
+		if skip[u.Raw] {
+			continue
+		}
+
+		e, err := s.loadEntity(u)
+		if err != nil {
+			return nil, err
+		}
+
+		if !rewriteReferences(e, renamed) {
+			continue
+		}
+
+		bumpVersion(e, now)
+
+		if err := s.saveEntity(u, e); err != nil {
+			return nil, err
+		}
+
+		updated = append(updated, redirected{uri: u, version: entityVersion(e)})
+	}
+
+	return updated, nil
+}
+
+// checkAll runs the entity checks on every entity in uris, as stored.
+func (s *Service) checkAll(uris []*uri.URI) error {
+	for _, u := range uris {
+		e, err := s.loadEntity(u)
+		if err != nil {
+			return err
+		}
+
+		if err := s.runChecks(u, e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rewriteReferences replaces every tag, relation, broader/narrower and
+// inverse-of reference of e found in renamed with its new URI, and reports
+// whether e changed. References that end up listed twice are kept once.
+func rewriteReferences(e any, renamed map[string]string) bool {
+	switch v := e.(type) {
+	case *model.Tag:
+		broader := renameAll(&v.Broader, renamed)
+		narrower := renameAll(&v.Narrower, renamed)
+		return broader || narrower
+	case *model.RelationType:
+		if target, ok := renamed[v.InverseOf]; ok {
+			v.InverseOf = target
+			return true
+		}
+
+		return false
+	case *model.Context:
+		tags := renameAll(&v.Tags, renamed)
+		relations := renameRelations(&v.Relations, renamed)
+		return tags || relations
+	case *model.Domain:
+		tags := renameAll(&v.Tags, renamed)
+		relations := renameRelations(&v.Relations, renamed)
+		return tags || relations
+	case *model.Concept:
+		tags := renameAll(&v.Tags, renamed)
+		relations := renameRelations(&v.Relations, renamed)
+		return tags || relations
+	default:
+		return false
+	}
+}
+
+func renameAll(values *[]string, renamed map[string]string) bool {
+	changed := false
+
+	for i, v := range *values {
+		if target, ok := renamed[v]; ok {
+			(*values)[i] = target
+			changed = true
+		}
+	}
+
+	if changed {
+		*values = uniqueStrings(*values)
+	}
+
+	return changed
+}
+
+func renameRelations(refs *[]model.RelationRef, renamed map[string]string) bool {
+	changed := false
+
+	for i, ref := range *refs {
+		if target, ok := renamed[ref.Type]; ok {
+			(*refs)[i].Type = target
+			changed = true
+		}
+
+		if target, ok := renamed[ref.Target]; ok {
+			(*refs)[i].Target = target
+			changed = true
+		}
+	}
+
+	if changed {
+		*refs = uniqueRelations(*refs)
+	}
+
+	return changed
+}
+
+// uniqueRelations returns refs without duplicates, keeping the first
+// occurrence of each.
+func uniqueRelations(refs []model.RelationRef) []model.RelationRef {
+	unique := make([]model.RelationRef, 0, len(refs))
+
+	for _, ref := range refs {
+		if !slices.Contains(unique, ref) {
+			unique = append(unique, ref)
+		}
+	}
+
+	return unique
+}
+
+// relativeFileName returns the file holding u, relative to the root
+// directory.
+func (s *Service) relativeFileName(u *uri.URI) string {
+	name := storage.FileName(s.rootDir, u)
+
+	if rel, err := filepath.Rel(s.rootDir, name); err == nil {
+		return filepath.ToSlash(rel)
+	}
+
+	return name
+}
@@ -0,0 +1,211 @@
+package service_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+const rebateURI = domainURI + "/concepts/rebate"
+
+func TestMove_Concept(t *testing.T) {
+	// given
+	svc := newService(t)
+	populateGraph(t, svc)
+	// when
+	result, err := svc.Move(conceptURI, rebateURI, 1)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, &service.MoveResult{
+		From:    conceptURI,
+		To:      rebateURI,
+		Moved:   []service.MovedEntity{{From: conceptURI, To: rebateURI, Version: 2}},
+		Updated: []service.UpdatedEntity{{URI: campaignURI, Version: 2}},
+		Files: []string{
+			"contexts/ecommerce/domains/pricing/discount.md",
+			"contexts/ecommerce/domains/pricing/rebate.md",
+			"contexts/ecommerce/domains/pricing/campaign.md",
+		},
+	}, result)
+
+	assert.NoFileExists(t, filepath.Join(svc.RootDir(), "contexts/ecommerce/domains/pricing/discount.md"))
+
+	rebate, err := svc.GetConcept(rebateURI)
+	require.NoError(t, err)
+	assert.Equal(t, rebateURI, rebate.URI)
+	assert.Equal(t, "Discount", rebate.Name)
+	assert.Equal(t, []model.RelationRef{
+		{Type: dependsRelation, Target: otherConceptURI},
+		{Type: partOfRelation, Target: domainURI},
+	}, rebate.Relations)
+
+	campaign, err := svc.GetConcept(campaignURI)
+	require.NoError(t, err)
+	assert.Equal(t, []model.RelationRef{{Type: partOfRelation, Target: rebateURI}}, campaign.Relations)
+}
+
+func TestMove_DomainToAnotherContext(t *testing.T) {
+	// given
+	svc, _ := newIndexedService(t)
+	populateGraph(t, svc)
+	_, err := svc.CreateContext(&model.Context{URI: logisticsURI})
+	require.NoError(t, err)
+	movedDomain := logisticsURI + "/domains/pricing"
+	movedDiscount := movedDomain + "/concepts/discount"
+	// when
+	result, err := svc.Move(domainURI, movedDomain, 1)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []service.MovedEntity{
+		{From: domainURI, To: movedDomain, Version: 2},
+		{From: campaignURI, To: movedDomain + "/concepts/campaign", Version: 2},
+		{From: otherConceptURI, To: movedDomain + "/concepts/coupon", Version: 2},
+		{From: conceptURI, To: movedDiscount, Version: 2},
+		{From: voucherURI, To: movedDomain + "/concepts/voucher", Version: 2},
+	}, result.Moved)
+	assert.Empty(t, result.Updated)
+	assert.Len(t, result.Files, 10)
+	assert.NoDirExists(t, filepath.Join(svc.RootDir(), "contexts/ecommerce/domains/pricing"))
+
+	discount, err := svc.GetConcept(movedDiscount)
+	require.NoError(t, err)
+	assert.Equal(t, []model.RelationRef{
+		{Type: dependsRelation, Target: movedDomain + "/concepts/coupon"},
+		{Type: partOfRelation, Target: movedDomain},
+	}, discount.Relations)
+
+	backlinks, err := svc.Backlinks(movedDiscount)
+	require.NoError(t, err)
+	require.Len(t, backlinks.Groups, 1)
+	assert.Equal(t, []string{movedDomain + "/concepts/campaign"}, backlinks.Groups[0].Sources)
+}
+
+func TestMove_ContextWithLocalTag(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	localTag := contextURI + "/tags/checkout"
+	createTag(t, svc, &model.Tag{URI: localTag})
+	d, err := svc.GetDomain(domainURI)
+	require.NoError(t, err)
+	d.Tags = []string{localTag}
+	_, err = svc.UpdateDomain(d)
+	require.NoError(t, err)
+	// when
+	result, err := svc.Move(contextURI, logisticsURI, 1)
+	// then
+	require.NoError(t, err)
+	assert.Len(t, result.Moved, 3)
+
+	domain, err := svc.GetDomain(logisticsURI + "/domains/pricing")
+	require.NoError(t, err)
+	assert.Equal(t, []string{logisticsURI + "/tags/checkout"}, domain.Tags)
+	assert.Equal(t, 3, domain.Version)
+	assert.NoDirExists(t, filepath.Join(svc.RootDir(), "contexts/ecommerce"))
+}
+
+func TestMove_Tag(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	createTag(t, svc, &model.Tag{URI: pricingTag})
+	createTag(t, svc, &model.Tag{URI: discountTag, Broader: []string{pricingTag}})
+	_, err := svc.CreateConcept(&model.Concept{URI: conceptURI, Tags: []string{discountTag}})
+	require.NoError(t, err)
+	// when
+	result, err := svc.Move(discountTag, promoTag, 1)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []service.UpdatedEntity{
+		{URI: conceptURI, Version: 2},
+		{URI: pricingTag, Version: 3},
+	}, result.Updated)
+
+	pricing, err := svc.GetTag(pricingTag)
+	require.NoError(t, err)
+	assert.Equal(t, []string{promoTag}, pricing.Narrower)
+
+	promo, err := svc.GetTag(promoTag)
+	require.NoError(t, err)
+	assert.Equal(t, []string{pricingTag}, promo.Broader)
+
+	concept, err := svc.GetConcept(conceptURI)
+	require.NoError(t, err)
+	assert.Equal(t, []string{promoTag}, concept.Tags)
+}
+
+func TestMove_RelationType(t *testing.T) {
+	// given
+	svc := newService(t)
+	populateInference(t, svc)
+	belongsTo := "scio://relations/belongs-to"
+	// when
+	result, err := svc.Move(partOfRelation, belongsTo, 2)
+	// then
+	require.NoError(t, err)
+	assert.Len(t, result.Updated, 3)
+
+	hasPart, err := svc.GetRelationType(hasPartRelation)
+	require.NoError(t, err)
+	assert.Equal(t, belongsTo, hasPart.InverseOf)
+
+	discount, err := svc.GetConcept(conceptURI)
+	require.NoError(t, err)
+	assert.Equal(t, []model.RelationRef{{Type: belongsTo, Target: otherConceptURI}}, discount.Relations)
+}
+
+func TestMove_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		version int
+		code    string
+	}{
+		{"invalid uri", "scio://nowhere", rebateURI, 1, outputs.ErrInvalidURIFormat},
+		{"not found", domainURI + "/concepts/missing", rebateURI, 1, outputs.ErrNotFound},
+		{"version conflict", conceptURI, rebateURI, 3, outputs.ErrVersionConflict},
+		{"entity type change", conceptURI, contextURI + "/domains/rebate", 1, outputs.ErrTypeMismatch},
+		{"same uri", conceptURI, conceptURI, 1, outputs.ErrValidationFailed},
+		{"target exists", conceptURI, otherConceptURI, 1, outputs.ErrAlreadyExists},
+		{"missing parent", conceptURI, contextURI + "/domains/billing/concepts/discount", 1, outputs.ErrParentNotFound},
+	}
+
+	svc := newService(t)
+	populateGraph(t, svc)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.Move(tc.from, tc.to, tc.version)
+			requireAppError(t, err, tc.code)
+
+			campaign, err := svc.GetConcept(campaignURI)
+			require.NoError(t, err)
+			assert.Equal(t, 1, campaign.Version)
+			assert.Equal(t, []model.RelationRef{{Type: partOfRelation, Target: conceptURI}}, campaign.Relations)
+		})
+	}
+}
+
+func TestMove_ScopeViolation(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	localTag := contextURI + "/tags/checkout"
+	createTag(t, svc, &model.Tag{URI: localTag})
+	_, err := svc.CreateConcept(&model.Concept{URI: conceptURI, Tags: []string{localTag}})
+	require.NoError(t, err)
+	_, err = svc.CreateContext(&model.Context{URI: logisticsURI})
+	require.NoError(t, err)
+	_, err = svc.CreateDomain(&model.Domain{URI: shippingURI})
+	require.NoError(t, err)
+	// when
+	_, err = svc.Move(conceptURI, freeShippingURI, 1)
+	// then
+	requireAppError(t, err, outputs.ErrScopeViolation)
+	_, err = svc.GetConcept(conceptURI)
+	require.NoError(t, err)
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// overlayStore buffers writes on top of a base store so that a batch can be
+// validated against its own pending entities and then either committed as a
+// whole or dropped.
+type overlayStore struct {
+	base    store
+	pending map[string]*pendingFile
+	// touched holds every URI written or removed, in first-touch order.
+	touched []*uri.URI
+	journal []journalEntry
+}
+
+type pendingFile struct {
+	content []byte
+	removed bool
+}
+
+type journalEntry struct {
+	u       *uri.URI
+	content []byte
+	remove  bool
+}
+
+func newOverlayStore(base store) *overlayStore {
+	return &overlayStore{
+		base:    base,
+		pending: make(map[string]*pendingFile),
+	}
+}
+
+func (o *overlayStore) exists(u *uri.URI) bool {
+	if p, ok := o.pending[u.Raw]; ok {
+		return !p.removed
+	}
+
+	return o.base.exists(u)
+}
+
+func (o *overlayStore) read(u *uri.URI) ([]byte, error) {
+	if p, ok := o.pending[u.Raw]; ok {
+		if p.removed {
+			return nil, fs.ErrNotExist
+		}
+
+		return slices.Clone(p.content), nil
+	}
+
+	return o.base.read(u)
+}
+
+func (o *overlayStore) write(u *uri.URI, content []byte) error {
+	o.touch(u, &pendingFile{content: slices.Clone(content)})
+	o.journal = append(o.journal, journalEntry{u: u, content: slices.Clone(content)})
+	return nil
+}
+
+func (o *overlayStore) remove(u *uri.URI) error {
+	if u.Entity == model.EntityTypeContext || u.Entity == model.EntityTypeDomain {
+		all, err := o.list()
+		if err != nil {
+			return err
+		}
+
+		for _, other := range all {
+			if contains(u, other) {
+				o.touch(other, &pendingFile{removed: true})
+			}
+		}
+	}
+
+	o.touch(u, &pendingFile{removed: true})
+	o.journal = append(o.journal, journalEntry{u: u, remove: true})
+	return nil
+}
+
+func (o *overlayStore) list() ([]*uri.URI, error) {
+	all, err := o.base.list()
+	if err != nil {
+		return nil, err
+	}
+
+	listed := make(map[string]bool, len(all))
+	all = slices.DeleteFunc(all, func(u *uri.URI) bool {
+		listed[u.Raw] = true
+		p, ok := o.pending[u.Raw]
+		return ok && p.removed
+	})
+
+	for _, u := range o.touched {
+		if !listed[u.Raw] && !o.pending[u.Raw].removed {
+			all = append(all, u)
+		}
+	}
+
+	return all, nil
+}
+
+func (o *overlayStore) touch(u *uri.URI, p *pendingFile) {
+	if _, ok := o.pending[u.Raw]; !ok {
+		o.touched = append(o.touched, u)
+	}
+
+	o.pending[u.Raw] = p
+}
+
+// commit replays every buffered change on the base store. If a change fails,
+// the entities already changed are restored to their original content.
+func (o *overlayStore) commit() error {
+	originals := make(map[string][]byte, len(o.touched))
+
+	for _, u := range o.touched {
+		content, err := o.base.read(u)
+
+		switch {
+		case err == nil:
+			originals[u.Raw] = content
+		case !errors.Is(err, fs.ErrNotExist):
+			return fmt.Errorf("failed to snapshot %s: %w", u, err)
+		}
+	}
+
+	for _, entry := range o.journal {
+		var err error
+
+		if entry.remove {
+			err = o.base.remove(entry.u)
+		} else {
+			err = o.base.write(entry.u, entry.content)
+		}
+
+		if err != nil {
+			if rollbackErr := o.rollback(originals); rollbackErr != nil {
+				return fmt.Errorf("failed to apply change to %s: %w (rollback failed: %w)", entry.u, err, rollbackErr)
+			}
+
+			return fmt.Errorf("failed to apply change to %s: %w", entry.u, err)
+		}
+	}
+
+	return nil
+}
+
+func (o *overlayStore) rollback(originals map[string][]byte) error {
+	var errs []error
+
+	for _, u := range slices.Backward(o.touched) {
+		original, existed := originals[u.Raw]
+
+		switch {
+		case existed:
+			errs = append(errs, o.base.write(u, original))
+		case o.base.exists(u):
+			errs = append(errs, o.base.remove(u))
+		}
+	}
+
+	return errors.Join(errs...)
+}
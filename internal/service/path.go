@@ -0,0 +1,293 @@
+package service
+
+import (
+	"slices"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// Kinds of hop a path can be made of.
+const (
+	HopRelation  = "relation"
+	HopTag       = "tag"
+	HopHierarchy = "hierarchy"
+)
+
+// Directions of a hop.
+const (
+	HopOutgoing = "outgoing"
+	HopIncoming = "incoming"
+	HopShared   = "shared"
+	HopToParent = "to_parent"
+	HopToChild  = "to_child"
+)
+
+const (
+	defaultPathDepth = 6
+	maxPathDepth     = 10
+	defaultPathLimit = 5
+)
+
+// PathQuery asks for the shortest paths between two entities. Relations are
+// always followed in both directions; IncludeTags also connects entities
+// sharing a tag, and IncludeHierarchy connects contexts, domains and
+// concepts with their parents and children. MaxDepth bounds the path length
+// and Limit the number of paths returned.
+type PathQuery struct {
+	From             string
+	To               string
+	IncludeTags      bool
+	IncludeHierarchy bool
+	MaxDepth         int
+	Limit            int
+}
+
+// PathHop is one step of a path. Type is the relation type URI of relation
+// hops and the shared tag URI of tag hops. Direction tells whether From
+// holds the relation (outgoing) or is its target (incoming), and which way
+// hierarchy hops go.
+type PathHop struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Kind      string `json:"kind"`
+	Type      string `json:"type,omitempty"`
+	Direction string `json:"direction"`
+}
+
+// Path is a sequence of hops leading from one entity to another.
+type Path struct {
+	Hops []PathHop `json:"hops"`
+}
+
+// PathResult holds the shortest paths found, all of the same Length.
+type PathResult struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Found  bool   `json:"found"`
+	Length int    `json:"length"`
+	Paths  []Path `json:"paths"`
+}
+
+// ShortestPaths finds the shortest paths between q.From and q.To.
+func (s *Service) ShortestPaths(q PathQuery) (*PathResult, error) {
+	from, err := s.existingURI(q.From)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := s.existingURI(q.To)
+	if err != nil {
+		return nil, err
+	}
+
+	maxDepth := q.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = defaultPathDepth
+	}
+
+	if maxDepth < 0 || maxDepth > maxPathDepth {
+		return nil, invalidArgumentError("max_depth", "max depth must be between 1 and 10", map[string]any{"value": q.MaxDepth})
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultPathLimit
+	}
+
+	hops, err := s.newHopFinder(q)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PathResult{From: from.Raw, To: to.Raw, Paths: []Path{}}
+
+	if from.Raw == to.Raw {
+		result.Found = true
+		result.Paths = append(result.Paths, Path{Hops: []PathHop{}})
+		return result, nil
+	}
+
+	// predecessors holds, for every node reached, the hops reaching it from
+	// the previous BFS level.
+	predecessors := map[string][]PathHop{from.Raw: nil}
+	frontier := []string{from.Raw}
+
+	for depth := 1; depth <= maxDepth && len(frontier) > 0 && !result.Found; depth++ {
+		reached := make(map[string][]PathHop)
+
+		var next []string
+
+		for _, node := range frontier {
+			neighbours, err := hops.from(node)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, hop := range neighbours {
+				if _, seen := predecessors[hop.To]; seen {
+					continue
+				}
+
+				if _, ok := reached[hop.To]; !ok {
+					next = append(next, hop.To)
+				}
+
+				reached[hop.To] = append(reached[hop.To], hop)
+			}
+		}
+
+		for node, reaching := range reached {
+			predecessors[node] = reaching
+		}
+
+		if _, ok := reached[to.Raw]; ok {
+			result.Found = true
+			result.Length = depth
+		}
+
+		frontier = next
+	}
+
+	if result.Found {
+		result.Paths = collectPaths(predecessors, from.Raw, to.Raw, limit)
+	}
+
+	return result, nil
+}
+
+// collectPaths walks the predecessors back from to, returning up to limit
+// paths in hop order.
+func collectPaths(predecessors map[string][]PathHop, from, to string, limit int) []Path {
+	var paths []Path
+
+	var walk func(node string, suffix []PathHop)
+	walk = func(node string, suffix []PathHop) {
+		if len(paths) >= limit {
+			return
+		}
+
+		if node == from {
+			hops := slices.Clone(suffix)
+			slices.Reverse(hops)
+			paths = append(paths, Path{Hops: hops})
+			return
+		}
+
+		for _, hop := range predecessors[node] {
+			walk(hop.From, append(suffix, hop))
+		}
+	}
+
+	walk(to, nil)
+	return paths
+}
+
+func (s *Service) existingURI(raw string) (*uri.URI, error) {
+	u, err := uri.Parse(raw)
+	if err != nil {
+		return nil, invalidURIError(raw, err)
+	}
+
+	if !s.exists(u) {
+		return nil, notFoundError(u.Raw)
+	}
+
+	return u, nil
+}
+
+// hopFinder lists the hops leaving a node.
+type hopFinder struct {
+	relations relationGraph
+	// tags and tagged are only set when tag hops are included.
+	tags   map[string][]string
+	tagged map[string][]string
+	// children is only set when hierarchy hops are included.
+	children map[string][]string
+}
+
+func (s *Service) newHopFinder(q PathQuery) (*hopFinder, error) {
+	relations, err := s.relationGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	f := &hopFinder{relations: relations}
+
+	if !q.IncludeTags && !q.IncludeHierarchy {
+		return f, nil
+	}
+
+	candidates, err := s.searchCandidates(contentEntities)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.IncludeTags {
+		f.tags = make(map[string][]string)
+		f.tagged = make(map[string][]string)
+
+		for _, c := range candidates {
+			f.tags[c.uri.Raw] = c.tags
+
+			for _, tag := range c.tags {
+				f.tagged[tag] = append(f.tagged[tag], c.uri.Raw)
+			}
+		}
+	}
+
+	if q.IncludeHierarchy {
+		f.children = make(map[string][]string)
+
+		for _, c := range candidates {
+			if parent, err := c.uri.ParentURI(); err == nil {
+				f.children[parent] = append(f.children[parent], c.uri.Raw)
+			}
+		}
+	}
+
+	return f, nil
+}
+
+func (f *hopFinder) from(node string) ([]PathHop, error) {
+	var hops []PathHop
+
+	out, err := f.relations.outgoing(node)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range out {
+		hops = append(hops, PathHop{From: node, To: e.Target, Kind: HopRelation, Type: e.Type, Direction: HopOutgoing})
+	}
+
+	in, err := f.relations.incoming(node)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range in {
+		hops = append(hops, PathHop{From: node, To: e.Source, Kind: HopRelation, Type: e.Type, Direction: HopIncoming})
+	}
+
+	for _, tag := range f.tags[node] {
+		for _, other := range f.tagged[tag] {
+			if other != node {
+				hops = append(hops, PathHop{From: node, To: other, Kind: HopTag, Type: tag, Direction: HopShared})
+			}
+		}
+	}
+
+	if f.children != nil {
+		if u, err := uri.Parse(node); err == nil {
+			if parent, err := u.ParentURI(); err == nil {
+				hops = append(hops, PathHop{From: node, To: parent, Kind: HopHierarchy, Direction: HopToParent})
+			}
+		}
+
+		for _, child := range f.children[node] {
+			hops = append(hops, PathHop{From: node, To: child, Kind: HopHierarchy, Direction: HopToChild})
+		}
+	}
+
+	return hops, nil
+}
@@ -0,0 +1,190 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+func TestShortestPaths(t *testing.T) {
+	// given
+	svc, _ := newIndexedService(t)
+	populateGraph(t, svc)
+	// when
+	result, err := svc.ShortestPaths(service.PathQuery{From: campaignURI, To: voucherURI})
+	// then
+	require.NoError(t, err)
+	assert.True(t, result.Found)
+	assert.Equal(t, 3, result.Length)
+	assert.Equal(t, []service.Path{{Hops: []service.PathHop{
+		{From: campaignURI, To: conceptURI, Kind: service.HopRelation, Type: partOfRelation, Direction: service.HopOutgoing},
+		{From: conceptURI, To: otherConceptURI, Kind: service.HopRelation, Type: dependsRelation, Direction: service.HopOutgoing},
+		{From: otherConceptURI, To: voucherURI, Kind: service.HopRelation, Type: dependsRelation, Direction: service.HopOutgoing},
+	}}}, result.Paths)
+}
+
+func TestShortestPaths_Incoming(t *testing.T) {
+	// given
+	svc := newService(t)
+	populateGraph(t, svc)
+	// when
+	result, err := svc.ShortestPaths(service.PathQuery{From: otherConceptURI, To: campaignURI})
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []service.Path{{Hops: []service.PathHop{
+		{From: otherConceptURI, To: conceptURI, Kind: service.HopRelation, Type: dependsRelation, Direction: service.HopIncoming},
+		{From: conceptURI, To: campaignURI, Kind: service.HopRelation, Type: partOfRelation, Direction: service.HopIncoming},
+	}}}, result.Paths)
+}
+
+func TestShortestPaths_AllShortest(t *testing.T) {
+	// given
+	svc, _ := newIndexedService(t)
+	populateGraph(t, svc)
+	c, err := svc.GetConcept(campaignURI)
+	require.NoError(t, err)
+	c.Relations = append(c.Relations, model.RelationRef{Type: dependsRelation, Target: voucherURI})
+	_, err = svc.UpdateConcept(c)
+	require.NoError(t, err)
+	// when
+	result, err := svc.ShortestPaths(service.PathQuery{From: conceptURI, To: voucherURI})
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Length)
+	assert.Equal(t, []service.Path{
+		{Hops: []service.PathHop{
+			{From: conceptURI, To: otherConceptURI, Kind: service.HopRelation, Type: dependsRelation, Direction: service.HopOutgoing},
+			{From: otherConceptURI, To: voucherURI, Kind: service.HopRelation, Type: dependsRelation, Direction: service.HopOutgoing},
+		}},
+		{Hops: []service.PathHop{
+			{From: conceptURI, To: campaignURI, Kind: service.HopRelation, Type: partOfRelation, Direction: service.HopIncoming},
+			{From: campaignURI, To: voucherURI, Kind: service.HopRelation, Type: dependsRelation, Direction: service.HopOutgoing},
+		}},
+	}, result.Paths)
+
+	// when
+	result, err = svc.ShortestPaths(service.PathQuery{From: conceptURI, To: voucherURI, Limit: 1})
+	// then
+	require.NoError(t, err)
+	assert.Len(t, result.Paths, 1)
+}
+
+func TestShortestPaths_MaxDepth(t *testing.T) {
+	// given
+	svc := newService(t)
+	populateGraph(t, svc)
+	// when
+	result, err := svc.ShortestPaths(service.PathQuery{From: campaignURI, To: voucherURI, MaxDepth: 2})
+	// then
+	require.NoError(t, err)
+	assert.False(t, result.Found)
+	assert.Empty(t, result.Paths)
+}
+
+func TestShortestPaths_TagsAndHierarchy(t *testing.T) {
+	// given
+	svc, _ := newIndexedService(t)
+	createTag(t, svc, &model.Tag{URI: pricingTag})
+	_, err := svc.Batch([]service.BatchOperation{
+		{Op: service.BatchCreate, URI: contextURI, Entity: &model.Context{URI: contextURI}},
+		{Op: service.BatchCreate, URI: domainURI, Entity: &model.Domain{URI: domainURI}},
+		{Op: service.BatchCreate, URI: logisticsURI, Entity: &model.Context{URI: logisticsURI}},
+		{Op: service.BatchCreate, URI: shippingURI, Entity: &model.Domain{URI: shippingURI}},
+		{Op: service.BatchCreate, URI: conceptURI, Entity: &model.Concept{URI: conceptURI, Tags: []string{pricingTag}}},
+		{Op: service.BatchCreate, URI: freeShippingURI, Entity: &model.Concept{URI: freeShippingURI, Tags: []string{pricingTag}}},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		query    service.PathQuery
+		found    bool
+		expected []service.PathHop
+	}{
+		{
+			name:  "relations only",
+			query: service.PathQuery{From: conceptURI, To: freeShippingURI},
+		},
+		{
+			name:  "shared tag",
+			query: service.PathQuery{From: conceptURI, To: freeShippingURI, IncludeTags: true},
+			found: true,
+			expected: []service.PathHop{
+				{From: conceptURI, To: freeShippingURI, Kind: service.HopTag, Type: pricingTag, Direction: service.HopShared},
+			},
+		},
+		{
+			name:  "hierarchy",
+			query: service.PathQuery{From: conceptURI, To: contextURI, IncludeHierarchy: true},
+			found: true,
+			expected: []service.PathHop{
+				{From: conceptURI, To: domainURI, Kind: service.HopHierarchy, Direction: service.HopToParent},
+				{From: domainURI, To: contextURI, Kind: service.HopHierarchy, Direction: service.HopToParent},
+			},
+		},
+		{
+			name:  "down the hierarchy",
+			query: service.PathQuery{From: logisticsURI, To: freeShippingURI, IncludeHierarchy: true},
+			found: true,
+			expected: []service.PathHop{
+				{From: logisticsURI, To: shippingURI, Kind: service.HopHierarchy, Direction: service.HopToChild},
+				{From: shippingURI, To: freeShippingURI, Kind: service.HopHierarchy, Direction: service.HopToChild},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := svc.ShortestPaths(tc.query)
+			require.NoError(t, err)
+			assert.Equal(t, tc.found, result.Found)
+
+			if !tc.found {
+				assert.Empty(t, result.Paths)
+				return
+			}
+
+			require.Len(t, result.Paths, 1)
+			assert.Equal(t, tc.expected, result.Paths[0].Hops)
+		})
+	}
+}
+
+func TestShortestPaths_SameEntity(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	// when
+	result, err := svc.ShortestPaths(service.PathQuery{From: domainURI, To: domainURI})
+	// then
+	require.NoError(t, err)
+	assert.True(t, result.Found)
+	assert.Zero(t, result.Length)
+	require.Len(t, result.Paths, 1)
+	assert.Empty(t, result.Paths[0].Hops)
+}
+
+func TestShortestPaths_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query service.PathQuery
+		code  string
+	}{
+		{"invalid from", service.PathQuery{From: "x", To: domainURI}, outputs.ErrInvalidURIFormat},
+		{"missing to", service.PathQuery{From: domainURI, To: conceptURI}, outputs.ErrNotFound},
+		{"depth too large", service.PathQuery{From: domainURI, To: contextURI, MaxDepth: 11}, outputs.ErrValidationFailed},
+	}
+
+	svc := newServiceWithDomain(t)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.ShortestPaths(tc.query)
+			requireAppError(t, err, tc.code)
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// Resolution tells where the entity a URI refers to is stored now. When the
+// URI was retired by a move or merge, Moved is set and Notice explains where
+// the entity went.
+type Resolution struct {
+	URI    string `json:"uri"`
+	Target string `json:"target"`
+	Entity string `json:"entity"`
+	Moved  bool   `json:"moved"`
+	Reason string `json:"reason,omitempty"`
+	Notice string `json:"notice,omitempty"`
+}
+
+// RedirectInfo is a stored redirect. TargetExists is false when the entity
+// the redirect points at was deleted since, which makes it safe to prune.
+type RedirectInfo struct {
+	From         string    `json:"from"`
+	To           string    `json:"to"`
+	Reason       string    `json:"reason"`
+	Created      time.Time `json:"created"`
+	TargetExists bool      `json:"target_exists"`
+}
+
+// Resolve looks rawURI up, following the redirect left behind when the
+// entity was moved or merged away.
+func (s *Service) Resolve(rawURI string) (*Resolution, error) {
+	u, err := uri.Parse(rawURI)
+	if err != nil {
+		return nil, invalidURIError(rawURI, err)
+	}
+
+	if s.exists(u) {
+		return &Resolution{URI: u.Raw, Target: u.Raw, Entity: u.Entity}, nil
+	}
+
+	redirects, err := s.loadRedirects()
+	if err != nil {
+		return nil, err
+	}
+
+	i := slices.IndexFunc(redirects, func(r model.Redirect) bool { return r.From == u.Raw })
+	if i < 0 {
+		return nil, notFoundError(u.Raw)
+	}
+
+	r := redirects[i]
+
+	verb := "was moved to"
+	if r.Reason == model.RedirectMerged {
+		verb = "was merged into"
+	}
+
+	return &Resolution{
+		URI:    u.Raw,
+		Target: r.To,
+		Entity: u.Entity,
+		Moved:  true,
+		Reason: r.Reason,
+		Notice: fmt.Sprintf("%s %s %s, use the new URI from now on", u.Raw, verb, r.To),
+	}, nil
+}
+
+// Redirects lists every stored redirect, ordered by retired URI.
+func (s *Service) Redirects() ([]RedirectInfo, error) {
+	redirects, err := s.loadRedirects()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]RedirectInfo, 0, len(redirects))
+
+	for _, r := range redirects {
+		info := RedirectInfo{
+			From:    r.From,
+			To:      r.To,
+			Reason:  r.Reason,
+			Created: r.Created,
+		}
+
+		if target, err := uri.Parse(r.To); err == nil {
+			info.TargetExists = s.exists(target)
+		}
+
+		infos = append(infos, info)
+	}
+
+	slices.SortFunc(infos, func(a, b RedirectInfo) int { return strings.Compare(a.From, b.From) })
+	return infos, nil
+}
+
+// DeleteRedirect prunes the redirect from the retired URI rawURI.
+func (s *Service) DeleteRedirect(rawURI string) error {
+	u, err := uri.Parse(rawURI)
+	if err != nil {
+		return invalidURIError(rawURI, err)
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	redirects, err := s.loadRedirects()
+	if err != nil {
+		return err
+	}
+
+	remaining := slices.DeleteFunc(slices.Clone(redirects), func(r model.Redirect) bool { return r.From == u.Raw })
+	if len(remaining) == len(redirects) {
+		return notFoundError(u.Raw)
+	}
+
+	return s.saveRedirects(remaining)
+}
+
+// followRedirect returns the URI that replaced u when u no longer exists and
+// a redirect of the same entity type was left behind, and u otherwise.
+func (s *Service) followRedirect(u *uri.URI) *uri.URI {
+	if s.exists(u) {
+		return u
+	}
+
+	redirects, err := s.loadRedirects()
+	if err != nil {
+		return u
+	}
+
+	for _, r := range redirects {
+		if r.From != u.Raw {
+			continue
+		}
+
+		if target, err := uri.Parse(r.To); err == nil && target.Entity == u.Entity {
+			return target
+		}
+	}
+
+	return u
+}
+
+// recordRedirects stores added, repointing earlier redirects that led to a
+// URI retired now, and dropping the redirects from URIs that are in use
+// again.
+func (s *Service) recordRedirects(added []model.Redirect) error {
+	redirects, err := s.loadRedirects()
+	if err != nil {
+		return err
+	}
+
+	replacements := make(map[string]string, len(added))
+	revived := make(map[string]bool, len(added))
+
+	for _, r := range added {
+		replacements[r.From] = r.To
+		revived[r.To] = true
+	}
+
+	for i, r := range redirects {
+		if to, ok := replacements[r.To]; ok {
+			redirects[i].To = to
+		}
+	}
+
+	redirects = slices.DeleteFunc(redirects, func(r model.Redirect) bool {
+		_, replaced := replacements[r.From]
+		return replaced || revived[r.From]
+	})
+
+	return s.saveRedirects(append(redirects, added...))
+}
+
+func (s *Service) loadRedirects() ([]model.Redirect, error) {
+	content, err := storage.ReadRedirects(s.rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read redirects: %w", err)
+	}
+
+	return model.ParseRedirects(string(content))
+}
+
+func (s *Service) saveRedirects(redirects []model.Redirect) error {
+	content, err := model.EncodeRedirects(redirects)
+	if err != nil {
+		return err
+	}
+
+	if err := storage.SaveRedirects(s.rootDir, []byte(content)); err != nil {
+		return fmt.Errorf("failed to write redirects: %w", err)
+	}
+
+	return s.commitRedirectsFile()
+}
@@ -0,0 +1,151 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+func redirectPairs(t *testing.T, svc *service.Service) map[string]string {
+	t.Helper()
+
+	redirects, err := svc.Redirects()
+	require.NoError(t, err)
+
+	pairs := make(map[string]string, len(redirects))
+	for _, r := range redirects {
+		pairs[r.From] = r.To
+	}
+
+	return pairs
+}
+
+func TestResolve_Moved(t *testing.T) {
+	// given
+	svc := newService(t)
+	populateGraph(t, svc)
+	_, err := svc.Move(conceptURI, rebateURI, 1)
+	require.NoError(t, err)
+	// when
+	resolution, err := svc.Resolve(conceptURI)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, &service.Resolution{
+		URI:    conceptURI,
+		Target: rebateURI,
+		Entity: model.EntityTypeConcept,
+		Moved:  true,
+		Reason: model.RedirectMoved,
+		Notice: conceptURI + " was moved to " + rebateURI + ", use the new URI from now on",
+	}, resolution)
+}
+
+func TestResolve_Existing(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	// when
+	resolution, err := svc.Resolve(domainURI)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, &service.Resolution{URI: domainURI, Target: domainURI, Entity: model.EntityTypeDomain}, resolution)
+}
+
+func TestResolve_Unknown(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	// when
+	_, err := svc.Resolve(conceptURI)
+	// then
+	requireAppError(t, err, outputs.ErrNotFound)
+}
+
+func TestGet_FollowsRedirect(t *testing.T) {
+	// given
+	svc := newService(t)
+	populateGraph(t, svc)
+	_, err := svc.Move(domainURI, contextURI+"/domains/prices", 1)
+	require.NoError(t, err)
+	// when
+	d, err := svc.GetDomain(domainURI)
+	require.NoError(t, err)
+	c, err := svc.GetConcept(conceptURI)
+	require.NoError(t, err)
+	// then
+	assert.Equal(t, contextURI+"/domains/prices", d.URI)
+	assert.Equal(t, contextURI+"/domains/prices/concepts/discount", c.URI)
+}
+
+func TestRedirects_Merged(t *testing.T) {
+	// given
+	svc := newService(t)
+	populateMerge(t, svc)
+	_, err := svc.MergeConcepts(service.MergeRequest{Source: otherConceptURI, SourceVersion: 1, Target: voucherURI, TargetVersion: 1})
+	require.NoError(t, err)
+	// when
+	redirects, err := svc.Redirects()
+	resolution, resolveErr := svc.Resolve(otherConceptURI)
+	// then
+	require.NoError(t, err)
+	require.Len(t, redirects, 1)
+	assert.Equal(t, otherConceptURI, redirects[0].From)
+	assert.Equal(t, voucherURI, redirects[0].To)
+	assert.Equal(t, model.RedirectMerged, redirects[0].Reason)
+	assert.True(t, redirects[0].TargetExists)
+
+	require.NoError(t, resolveErr)
+	assert.Contains(t, resolution.Notice, "was merged into")
+
+	c, err := svc.GetConcept(otherConceptURI)
+	require.NoError(t, err)
+	assert.Equal(t, voucherURI, c.URI)
+}
+
+func TestRedirects_Chains(t *testing.T) {
+	// given
+	svc := newService(t)
+	populateGraph(t, svc)
+	_, err := svc.Move(conceptURI, rebateURI, 1)
+	require.NoError(t, err)
+	// when
+	_, err = svc.Move(rebateURI, campaignURI+"-rebate", 2)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		conceptURI: campaignURI + "-rebate",
+		rebateURI:  campaignURI + "-rebate",
+	}, redirectPairs(t, svc))
+
+	// when — moving back revives the original URI
+	_, err = svc.Move(campaignURI+"-rebate", conceptURI, 3)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		rebateURI:               conceptURI,
+		campaignURI + "-rebate": conceptURI,
+	}, redirectPairs(t, svc))
+}
+
+func TestDeleteRedirect(t *testing.T) {
+	// given
+	svc := newService(t)
+	populateGraph(t, svc)
+	_, err := svc.Move(conceptURI, rebateURI, 1)
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteConcept(rebateURI))
+
+	redirects, err := svc.Redirects()
+	require.NoError(t, err)
+	require.Len(t, redirects, 1)
+	assert.False(t, redirects[0].TargetExists)
+	// when
+	err = svc.DeleteRedirect(conceptURI)
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, redirectPairs(t, svc))
+	requireAppError(t, svc.DeleteRedirect(conceptURI), outputs.ErrNotFound)
+}
@@ -0,0 +1,375 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// GetRelationType reads the relation type at rawURI, following the redirect
+// left behind if it was moved.
+func (s *Service) GetRelationType(rawURI string) (*model.RelationType, error) {
+	u, err := parseURI(rawURI, model.EntityTypeRelation)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.loadRelationType(s.followRedirect(u))
+}
+
+// CreateRelationType stores a new relation type at version 1. When InverseOf
+// is set, the inverse relation type is updated to point back, in the same
+// transaction.
+func (s *Service) CreateRelationType(r *model.RelationType) (*model.RelationType, error) {
+	u, err := parseURI(r.URI, model.EntityTypeRelation)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if s.exists(u) {
+		return nil, alreadyExistsError(u.Raw)
+	}
+
+	created := s.normalizeRelationType(r)
+
+	if err := s.checkEntity(u, created); err != nil {
+		return nil, err
+	}
+
+	now := s.clock()
+	created.Entity = model.EntityTypeRelation
+	created.Schema = model.SchemaVersion
+	created.Version = 1
+	created.Created = now
+	created.LastUpdate = now
+
+	err = s.atomically(func(tx *Service) error {
+		if err := tx.saveRelationType(u, created); err != nil {
+			return err
+		}
+
+		return tx.syncInverseLink(created, nil, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateRelationType replaces the stored relation type, provided r.Version
+// still matches the version on disk. Inverse back-pointers follow changes
+// to InverseOf, and are written together with the relation type.
+func (s *Service) UpdateRelationType(r *model.RelationType) (*model.RelationType, error) {
+	u, err := parseURI(r.URI, model.EntityTypeRelation)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	current, err := s.loadRelationType(u)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.Version != r.Version {
+		return nil, versionConflictError(u.Raw, r.Version, current.Version)
+	}
+
+	updated := s.normalizeRelationType(r)
+
+	if err := s.checkEntity(u, updated); err != nil {
+		return nil, err
+	}
+
+	now := s.clock()
+	updated.Entity = model.EntityTypeRelation
+	updated.Schema = model.SchemaVersion
+	updated.Version = current.Version + 1
+	updated.Created = current.Created
+	updated.LastUpdate = now
+
+	err = s.atomically(func(tx *Service) error {
+		if err := tx.saveRelationType(u, updated); err != nil {
+			return err
+		}
+
+		return tx.syncInverseLink(updated, current, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteRelationType removes a relation type no entity relation still uses,
+// clearing the back-pointer of its inverse as a whole.
+func (s *Service) DeleteRelationType(rawURI string) error {
+	u, err := parseURI(rawURI, model.EntityTypeRelation)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	current, err := s.loadRelationType(u)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkUnused(u); err != nil {
+		return err
+	}
+
+	return s.atomically(func(tx *Service) error {
+		if err := tx.deleteFile(u); err != nil {
+			return err
+		}
+
+		return tx.syncInverseLink(&model.RelationType{URI: u.Raw}, current, s.clock())
+	})
+}
+
+// normalizeRelationType returns a copy of r with default allowed entities
+// and InverseOf resolved to a full URI.
+func (s *Service) normalizeRelationType(r *model.RelationType) *model.RelationType {
+	normalized := *r
+	s.validator.Resolve(&normalized)
+
+	if normalized.AllowedSourceEntities == nil {
+		normalized.AllowedSourceEntities = slices.Clone(contentEntities)
+	}
+
+	if normalized.AllowedTargetEntities == nil {
+		normalized.AllowedTargetEntities = slices.Clone(contentEntities)
+	}
+
+	return &normalized
+}
+
+// checkRelationType validates the allowed entity lists of r and that its
+// symmetric, transitive and inverse-of properties agree with each other and
+// with the inverse relation type.
+func (s *Service) checkRelationType(r *model.RelationType) error {
+	allowedLists := []struct {
+		field       string
+		entityTypes []string
+	}{
+		{"allowed_source_entities", r.AllowedSourceEntities},
+		{"allowed_target_entities", r.AllowedTargetEntities},
+	}
+
+	for _, list := range allowedLists {
+		for _, entityType := range list.entityTypes {
+			if !slices.Contains(contentEntities, entityType) {
+				return validationError(r.URI, fmt.Sprintf("entity type %q cannot take part in relations", entityType), map[string]any{
+					"field":   list.field,
+					"value":   entityType,
+					"allowed": contentEntities,
+				})
+			}
+		}
+	}
+
+	if r.Symmetric && r.InverseOf != "" {
+		return relationPropertiesConflictError(r.URI, "a symmetric relation is its own inverse and cannot declare inverse-of", map[string]any{
+			"inverse_of": r.InverseOf,
+		})
+	}
+
+	if r.Symmetric && !sameElements(r.AllowedSourceEntities, r.AllowedTargetEntities) {
+		return relationPropertiesConflictError(r.URI, "a symmetric relation must allow the same source and target entities", map[string]any{
+			"allowed_source_entities": r.AllowedSourceEntities,
+			"allowed_target_entities": r.AllowedTargetEntities,
+		})
+	}
+
+	if r.InverseOf == "" {
+		return nil
+	}
+
+	if r.InverseOf == r.URI {
+		return relationPropertiesConflictError(r.URI, "a relation cannot be its own inverse, mark it symmetric instead", map[string]any{
+			"inverse_of": r.InverseOf,
+		})
+	}
+
+	if err := s.validator.Validate(r); err != nil {
+		return err
+	}
+
+	iu, err := uri.Parse(r.InverseOf)
+	if err != nil {
+		return err
+	}
+
+	inverse, err := s.loadRelationType(iu)
+	if err != nil {
+		return err
+	}
+
+	return checkInversePair(r, inverse)
+}
+
+// checkInversePair verifies that inverse can become the inverse of r.
+func checkInversePair(r, inverse *model.RelationType) error {
+	switch {
+	case inverse.InverseOf != "" && inverse.InverseOf != r.URI:
+		return relationPropertiesConflictError(r.URI, fmt.Sprintf("%s is already the inverse of %s", inverse.URI, inverse.InverseOf), map[string]any{
+			"inverse_of":            inverse.URI,
+			"inverse_of_inverse_of": inverse.InverseOf,
+		})
+
+	case inverse.Symmetric:
+		return relationPropertiesConflictError(r.URI, fmt.Sprintf("%s is symmetric and cannot have an inverse", inverse.URI), map[string]any{
+			"inverse_of": inverse.URI,
+		})
+
+	case inverse.Transitive != r.Transitive:
+		return relationPropertiesConflictError(r.URI, fmt.Sprintf("%s and its inverse must agree on transitivity", r.URI), map[string]any{
+			"inverse_of":         inverse.URI,
+			"transitive":         r.Transitive,
+			"inverse_transitive": inverse.Transitive,
+		})
+
+	case !sameElements(r.AllowedSourceEntities, inverse.AllowedTargetEntities) ||
+		!sameElements(r.AllowedTargetEntities, inverse.AllowedSourceEntities):
+		return relationPropertiesConflictError(r.URI, fmt.Sprintf("the allowed entities of %s are not the mirror image of %s", r.URI, inverse.URI), map[string]any{
+			"inverse_of":                      inverse.URI,
+			"allowed_source_entities":         r.AllowedSourceEntities,
+			"allowed_target_entities":         r.AllowedTargetEntities,
+			"inverse_allowed_source_entities": inverse.AllowedSourceEntities,
+			"inverse_allowed_target_entities": inverse.AllowedTargetEntities,
+		})
+	}
+
+	return nil
+}
+
+// syncInverseLink makes the inverse of r point back at r, and clears the
+// back-pointer of the previous inverse when it changed. previous is the
+// stored state of r before the change, or nil for a new relation type.
+func (s *Service) syncInverseLink(r, previous *model.RelationType, now time.Time) error {
+	if previous != nil && previous.InverseOf != "" && previous.InverseOf != r.InverseOf {
+		if err := s.updateLinkedRelationType(previous.InverseOf, now, func(linked *model.RelationType) bool {
+			if linked.InverseOf != r.URI {
+				return false
+			}
+
+			linked.InverseOf = ""
+			return true
+		}); err != nil {
+			return err
+		}
+	}
+
+	if r.InverseOf == "" {
+		return nil
+	}
+
+	return s.updateLinkedRelationType(r.InverseOf, now, func(linked *model.RelationType) bool {
+		if linked.InverseOf == r.URI {
+			return false
+		}
+
+		linked.InverseOf = r.URI
+		return true
+	})
+}
+
+// updateLinkedRelationType applies change to the relation type at rawURI and
+// saves it with a new version if change reports a modification. Dangling
+// links are skipped.
+func (s *Service) updateLinkedRelationType(rawURI string, now time.Time, change func(*model.RelationType) bool) error {
+	u, parseErr := uri.Parse(rawURI)
+	if parseErr != nil || !s.exists(u) {
+		return nil
+	}
+
+	linked, err := s.loadRelationType(u)
+	if err != nil {
+		return err
+	}
+
+	if !change(linked) {
+		return nil
+	}
+
+	linked.Version++
+	linked.LastUpdate = now
+	return s.saveRelationType(u, linked)
+}
+
+// entitiesRelatedBy returns the URIs of the entities holding at least one
+// relation of type relationURI.
+func (s *Service) entitiesRelatedBy(relationURI string) ([]string, error) {
+	if s.index != nil {
+		return s.index.RelatedBy(relationURI)
+	}
+
+	uris, err := s.listURIs(contentEntities...)
+	if err != nil {
+		return nil, err
+	}
+
+	var related []string
+
+	for _, u := range uris {
+		e, err := s.loadEntity(u)
+		if err != nil {
+			return nil, err
+		}
+
+		if slices.ContainsFunc(entityRelations(e), func(ref model.RelationRef) bool { return ref.Type == relationURI }) {
+			related = append(related, u.Raw)
+		}
+	}
+
+	return related, nil
+}
+
+func (s *Service) loadRelationType(u *uri.URI) (*model.RelationType, error) {
+	content, err := s.readFile(u)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := model.ParseRelationType(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", u, err)
+	}
+
+	if err := checkSchema(u.Raw, r.Schema); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (s *Service) saveRelationType(u *uri.URI, r *model.RelationType) error {
+	content, err := model.EncodeRelationType(r)
+	if err != nil {
+		return err
+	}
+
+	return s.writeFile(u, content)
+}
@@ -0,0 +1,212 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+const (
+	partOfRelation  = "scio://relations/part-of"
+	hasPartRelation = "scio://relations/has-part"
+	relatedRelation = "scio://relations/related-to"
+	dependsRelation = "scio://relations/depends-on"
+)
+
+func createRelationType(t *testing.T, svc *service.Service, r *model.RelationType) *model.RelationType {
+	t.Helper()
+
+	created, err := svc.CreateRelationType(r)
+	require.NoError(t, err)
+	return created
+}
+
+func getRelationType(t *testing.T, svc *service.Service, rawURI string) *model.RelationType {
+	t.Helper()
+
+	r, err := svc.GetRelationType(rawURI)
+	require.NoError(t, err)
+	return r
+}
+
+func TestCreateRelationType(t *testing.T) {
+	// given
+	svc := newService(t)
+	// when
+	r := createRelationType(t, svc, &model.RelationType{URI: partOfRelation, Transitive: true})
+	// then
+	assert.Equal(t, model.EntityTypeRelation, r.Entity)
+	assert.Equal(t, 1, r.Version)
+	assert.Equal(t, []string{"context", "domain", "concept"}, r.AllowedSourceEntities)
+	assert.True(t, getRelationType(t, svc, partOfRelation).Transitive)
+}
+
+func TestCreateRelationType_InverseIsLinkedBack(t *testing.T) {
+	// given
+	svc := newService(t)
+	createRelationType(t, svc, &model.RelationType{URI: partOfRelation, Transitive: true})
+	// when
+	createRelationType(t, svc, &model.RelationType{URI: hasPartRelation, Transitive: true, InverseOf: partOfRelation})
+	// then
+	partOf := getRelationType(t, svc, partOfRelation)
+	assert.Equal(t, hasPartRelation, partOf.InverseOf)
+	assert.Equal(t, 2, partOf.Version)
+}
+
+func TestCreateRelationType_InverseFailureRollsBack(t *testing.T) {
+	// given
+	svc, _ := newIndexedService(t)
+	createRelationType(t, svc, &model.RelationType{URI: partOfRelation})
+	failIndexing(t, svc.RootDir(), partOfRelation)
+	// when
+	_, err := svc.CreateRelationType(&model.RelationType{URI: hasPartRelation, InverseOf: partOfRelation})
+	// then
+	require.Error(t, err)
+	_, err = svc.GetRelationType(hasPartRelation)
+	requireAppError(t, err, outputs.ErrNotFound)
+	partOf := getRelationType(t, svc, partOfRelation)
+	assert.Empty(t, partOf.InverseOf)
+	assert.Equal(t, 1, partOf.Version)
+}
+
+func TestCreateRelationType_Conflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		relation *model.RelationType
+	}{
+		{
+			name:     "symmetric with inverse",
+			relation: &model.RelationType{URI: relatedRelation, Symmetric: true, InverseOf: partOfRelation},
+		},
+		{
+			name: "symmetric with different source and target",
+			relation: &model.RelationType{
+				URI:                   relatedRelation,
+				Symmetric:             true,
+				AllowedSourceEntities: []string{model.EntityTypeConcept},
+				AllowedTargetEntities: []string{model.EntityTypeDomain},
+			},
+		},
+		{
+			name:     "own inverse",
+			relation: &model.RelationType{URI: relatedRelation, InverseOf: relatedRelation},
+		},
+		{
+			name: "incompatible inverse entities",
+			relation: &model.RelationType{
+				URI:                   hasPartRelation,
+				Transitive:            true,
+				InverseOf:             partOfRelation,
+				AllowedSourceEntities: []string{model.EntityTypeConcept},
+			},
+		},
+		{
+			name:     "inverse with different transitivity",
+			relation: &model.RelationType{URI: hasPartRelation, InverseOf: partOfRelation},
+		},
+		{
+			name:     "inverse already paired",
+			relation: &model.RelationType{URI: hasPartRelation, Transitive: true, InverseOf: dependsRelation},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			svc := newService(t)
+			createRelationType(t, svc, &model.RelationType{URI: partOfRelation, Transitive: true})
+			createRelationType(t, svc, &model.RelationType{URI: dependsRelation, Transitive: true})
+			createRelationType(t, svc, &model.RelationType{URI: "scio://relations/required-by", Transitive: true, InverseOf: dependsRelation})
+			// when
+			_, err := svc.CreateRelationType(tc.relation)
+			// then
+			requireAppError(t, err, outputs.ErrRelationPropertiesConflict)
+		})
+	}
+}
+
+func TestCreateRelationType_InverseNotFound(t *testing.T) {
+	// given
+	svc := newService(t)
+	// when
+	_, err := svc.CreateRelationType(&model.RelationType{URI: hasPartRelation, InverseOf: partOfRelation})
+	// then
+	requireAppError(t, err, outputs.ErrRelationTypeNotFound)
+}
+
+func TestUpdateRelationType_MovesInverse(t *testing.T) {
+	// given
+	svc := newService(t)
+	createRelationType(t, svc, &model.RelationType{URI: partOfRelation})
+	createRelationType(t, svc, &model.RelationType{URI: dependsRelation})
+	hasPart := createRelationType(t, svc, &model.RelationType{URI: hasPartRelation, InverseOf: partOfRelation})
+	// when
+	updated, err := svc.UpdateRelationType(&model.RelationType{
+		URI:       hasPartRelation,
+		InverseOf: dependsRelation,
+		Version:   hasPart.Version,
+	})
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Version)
+	assert.Empty(t, getRelationType(t, svc, partOfRelation).InverseOf)
+	assert.Equal(t, hasPartRelation, getRelationType(t, svc, dependsRelation).InverseOf)
+}
+
+func TestUpdateRelationType_VersionConflict(t *testing.T) {
+	// given
+	svc := newService(t)
+	createRelationType(t, svc, &model.RelationType{URI: partOfRelation})
+	// when
+	_, err := svc.UpdateRelationType(&model.RelationType{URI: partOfRelation, Version: 9})
+	// then
+	requireAppError(t, err, outputs.ErrVersionConflict)
+}
+
+func TestDeleteRelationType_ClearsInverse(t *testing.T) {
+	// given
+	svc := newService(t)
+	createRelationType(t, svc, &model.RelationType{URI: partOfRelation})
+	createRelationType(t, svc, &model.RelationType{URI: hasPartRelation, InverseOf: partOfRelation})
+	// when
+	err := svc.DeleteRelationType(hasPartRelation)
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, getRelationType(t, svc, partOfRelation).InverseOf)
+}
+
+func TestDeleteRelationType_InverseFailureRollsBack(t *testing.T) {
+	// given
+	svc, _ := newIndexedService(t)
+	createRelationType(t, svc, &model.RelationType{URI: partOfRelation})
+	createRelationType(t, svc, &model.RelationType{URI: hasPartRelation, InverseOf: partOfRelation})
+	failIndexing(t, svc.RootDir(), partOfRelation)
+	// when
+	err := svc.DeleteRelationType(hasPartRelation)
+	// then
+	require.Error(t, err)
+	assert.Equal(t, partOfRelation, getRelationType(t, svc, hasPartRelation).InverseOf)
+	assert.Equal(t, hasPartRelation, getRelationType(t, svc, partOfRelation).InverseOf)
+}
+
+func TestDeleteRelationType_InUse(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	createRelationType(t, svc, &model.RelationType{URI: partOfRelation})
+	_, err := svc.CreateConcept(&model.Concept{
+		URI:       conceptURI,
+		Name:      "Discount",
+		Relations: []model.RelationRef{{Type: partOfRelation, Target: domainURI}},
+	})
+	require.NoError(t, err)
+	// when
+	err = svc.DeleteRelationType(partOfRelation)
+	// then
+	appErr := requireAppError(t, err, outputs.ErrRelationInUse)
+	assert.Equal(t, []string{conceptURI}, appErr.Details["referenced_by"])
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/index"
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+const defaultSearchLimit = 20
+
+// Fields a search query is matched against.
+const (
+	SearchFieldName = "name"
+	SearchFieldSlug = "slug"
+)
+
+// SearchQuery is a fuzzy lookup of contexts, domains and concepts. Context
+// is a context URI and Tag a tag URI or short slug, resolved in the scope of
+// Context; empty filters match everything.
+type SearchQuery struct {
+	Query       string
+	EntityTypes []string
+	Context     string
+	Tag         string
+	Limit       int
+}
+
+// SearchHit is an entity matching a search query. Positions are the byte
+// offsets of the matched characters in the Field that matched best.
+type SearchHit struct {
+	URI       string `json:"uri"`
+	Name      string `json:"name"`
+	Entity    string `json:"entity"`
+	Score     int    `json:"score"`
+	Field     string `json:"field"`
+	Positions []int  `json:"positions"`
+}
+
+// searchCandidate is an entity a search query may match.
+type searchCandidate struct {
+	uri     *uri.URI
+	context string
+	name    string
+	tags    []string
+}
+
+// Search fuzzy-matches q.Query against the names and URI slugs of every
+// context, domain and concept allowed by the filters, best match first.
+func (s *Service) Search(q SearchQuery) ([]SearchHit, error) {
+	if q.Query == "" {
+		return nil, invalidArgumentError("query", "a search query is required", map[string]any{})
+	}
+
+	if err := checkSearchTypes(q.EntityTypes); err != nil {
+		return nil, err
+	}
+
+	contextSlug := ""
+
+	if q.Context != "" {
+		u, err := parseURI(q.Context, model.EntityTypeContext)
+		if err != nil {
+			return nil, err
+		}
+
+		contextSlug = u.Slug
+	}
+
+	tag := ""
+	if q.Tag != "" {
+		tag = s.validator.ResolveTag(contextSlug, q.Tag)
+	}
+
+	entityTypes := q.EntityTypes
+	if len(entityTypes) == 0 {
+		entityTypes = contentEntities
+	}
+
+	candidates, err := s.searchCandidates(entityTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates = slices.DeleteFunc(candidates, func(c searchCandidate) bool {
+		return (contextSlug != "" && c.context != contextSlug) || (tag != "" && !slices.Contains(c.tags, tag))
+	})
+
+	hits := make(map[int]*SearchHit)
+
+	match := func(field string, value func(c searchCandidate) string) {
+		values := make([]string, len(candidates))
+		for i, c := range candidates {
+			values[i] = value(c)
+		}
+
+		for _, m := range fuzzy.Find(q.Query, values) {
+			if best, ok := hits[m.Index]; ok && best.Score >= m.Score {
+				continue
+			}
+
+			c := candidates[m.Index]
+			hits[m.Index] = &SearchHit{
+				URI:       c.uri.Raw,
+				Name:      c.name,
+				Entity:    c.uri.Entity,
+				Score:     m.Score,
+				Field:     field,
+				Positions: m.MatchedIndexes,
+			}
+		}
+	}
+
+	match(SearchFieldName, func(c searchCandidate) string { return c.name })
+	match(SearchFieldSlug, func(c searchCandidate) string { return c.uri.Slug })
+
+	ranked := make([]SearchHit, 0, len(hits))
+	for _, hit := range hits {
+		ranked = append(ranked, *hit)
+	}
+
+	slices.SortFunc(ranked, func(a, b SearchHit) int {
+		if a.Score != b.Score {
+			return cmp.Compare(b.Score, a.Score)
+		}
+
+		return cmp.Compare(a.URI, b.URI)
+	})
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	return ranked, nil
+}
+
+// searchCandidates returns every entity of the given types, from the index
+// when the service has one.
+func (s *Service) searchCandidates(entityTypes []string) ([]searchCandidate, error) {
+	if s.index != nil {
+		entries, err := s.index.Entries(entityTypes...)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := make([]searchCandidate, 0, len(entries))
+
+		for _, e := range entries {
+			u, err := uri.Parse(e.URI)
+			if err != nil {
+				continue
+			}
+
+			candidates = append(candidates, searchCandidate{uri: u, context: e.Context, name: e.Name, tags: e.Tags})
+		}
+
+		return candidates, nil
+	}
+
+	uris, err := s.listURIs(entityTypes...)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]searchCandidate, 0, len(uris))
+
+	for _, u := range uris {
+		e, err := s.loadEntity(u)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, searchCandidate{uri: u, context: entityContext(u), name: entityName(e), tags: entityTags(e)})
+	}
+
+	return candidates, nil
+}
+
+// TextSearchQuery is a full-text search of contexts, domains and concepts.
+// Context and Domain are URIs and Tag a tag URI or short slug, resolved in
+// the scope of Context or Domain; empty filters match everything.
+type TextSearchQuery struct {
+	Query       string
+	EntityTypes []string
+	Context     string
+	Domain      string
+	Tag         string
+	Limit       int
+}
+
+// TextSearchHit is an entity matching a full-text search, with an excerpt
+// of the matching text where matched terms are wrapped in **.
+type TextSearchHit struct {
+	URI     string  `json:"uri"`
+	Name    string  `json:"name"`
+	Entity  string  `json:"entity"`
+	Snippet string  `json:"snippet"`
+	Score   float64 `json:"score"`
+}
+
+// SearchText finds the contexts, domains and concepts whose name, body or
+// tags contain every word of q.Query, best match first. It requires the
+// service to have an index.
+func (s *Service) SearchText(q TextSearchQuery) ([]TextSearchHit, error) {
+	if s.index == nil {
+		return nil, errors.New("full-text search requires the index")
+	}
+
+	if strings.TrimSpace(q.Query) == "" {
+		return nil, invalidArgumentError("query", "a search query is required", map[string]any{})
+	}
+
+	if err := checkSearchTypes(q.EntityTypes); err != nil {
+		return nil, err
+	}
+
+	query := index.TextQuery{Query: q.Query, EntityTypes: q.EntityTypes, Limit: q.Limit}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultSearchLimit
+	}
+
+	if q.Context != "" {
+		u, err := parseURI(q.Context, model.EntityTypeContext)
+		if err != nil {
+			return nil, err
+		}
+
+		query.Context = u.Slug
+	}
+
+	if q.Domain != "" {
+		u, err := parseURI(q.Domain, model.EntityTypeDomain)
+		if err != nil {
+			return nil, err
+		}
+
+		query.Context = *u.Context
+		query.Domain = u.Slug
+	}
+
+	if q.Tag != "" {
+		query.Tag = s.validator.ResolveTag(query.Context, q.Tag)
+	}
+
+	found, err := s.index.SearchText(query)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]TextSearchHit, 0, len(found))
+
+	for _, h := range found {
+		hits = append(hits, TextSearchHit{URI: h.URI, Name: h.Name, Entity: h.Entity, Snippet: h.Snippet, Score: -h.Rank})
+	}
+
+	return hits, nil
+}
+
+func checkSearchTypes(entityTypes []string) error {
+	for _, t := range entityTypes {
+		if !slices.Contains(contentEntities, t) {
+			return invalidArgumentError("entity_types", "entity types must be context, domain or concept", map[string]any{
+				"value":   t,
+				"allowed": contentEntities,
+			})
+		}
+	}
+
+	return nil
+}
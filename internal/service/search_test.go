@@ -0,0 +1,213 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+const (
+	logisticsURI    = "scio://contexts/logistics"
+	shippingURI     = logisticsURI + "/domains/shipping"
+	freeShippingURI = shippingURI + "/concepts/free-shipping-discount"
+)
+
+// populateSearch stores two contexts with a domain each and concepts whose
+// names and slugs overlap.
+func populateSearch(t *testing.T, svc *service.Service) {
+	t.Helper()
+
+	createTag(t, svc, &model.Tag{URI: pricingTag})
+	_, err := svc.Batch([]service.BatchOperation{
+		{Op: service.BatchCreate, URI: contextURI, Entity: &model.Context{URI: contextURI, Name: "E-commerce"}},
+		{Op: service.BatchCreate, URI: domainURI, Entity: &model.Domain{URI: domainURI, Name: "Pricing"}},
+		{Op: service.BatchCreate, URI: conceptURI, Entity: &model.Concept{URI: conceptURI, Name: "Discount", Tags: []string{pricingTag}}},
+		{Op: service.BatchCreate, URI: otherConceptURI, Entity: &model.Concept{URI: otherConceptURI, Name: "Coupon Code"}},
+		{Op: service.BatchCreate, URI: logisticsURI, Entity: &model.Context{URI: logisticsURI, Name: "Logistics"}},
+		{Op: service.BatchCreate, URI: shippingURI, Entity: &model.Domain{URI: shippingURI, Name: "Shipping"}},
+		{Op: service.BatchCreate, URI: freeShippingURI, Entity: &model.Concept{URI: freeShippingURI, Name: "Free shipping"}},
+	})
+	require.NoError(t, err)
+}
+
+func hitURIs(hits []service.SearchHit) []string {
+	uris := make([]string, 0, len(hits))
+	for _, h := range hits {
+		uris = append(uris, h.URI)
+	}
+
+	return uris
+}
+
+func textHitURIs(hits []service.TextSearchHit) []string {
+	uris := make([]string, 0, len(hits))
+	for _, h := range hits {
+		uris = append(uris, h.URI)
+	}
+
+	return uris
+}
+
+func TestSearch(t *testing.T) {
+	services := map[string]func(t *testing.T) *service.Service{
+		"scan": newService,
+		"index": func(t *testing.T) *service.Service {
+			svc, _ := newIndexedService(t)
+			return svc
+		},
+	}
+
+	for name, newSvc := range services {
+		t.Run(name, func(t *testing.T) {
+			// given
+			svc := newSvc(t)
+			populateSearch(t, svc)
+			// when
+			hits, err := svc.Search(service.SearchQuery{Query: "discnt"})
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, []string{conceptURI, freeShippingURI}, hitURIs(hits))
+			assert.Equal(t, service.SearchHit{
+				URI:       conceptURI,
+				Name:      "Discount",
+				Entity:    model.EntityTypeConcept,
+				Score:     hits[0].Score,
+				Field:     service.SearchFieldName,
+				Positions: []int{0, 1, 2, 3, 6, 7},
+			}, hits[0])
+			assert.Equal(t, service.SearchFieldSlug, hits[1].Field)
+		})
+	}
+}
+
+func TestSearch_Filters(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    service.SearchQuery
+		expected []string
+	}{
+		{
+			name:     "by entity type",
+			query:    service.SearchQuery{Query: "shpg", EntityTypes: []string{model.EntityTypeDomain}},
+			expected: []string{shippingURI},
+		},
+		{
+			name:     "by context",
+			query:    service.SearchQuery{Query: "discount", Context: contextURI},
+			expected: []string{conceptURI},
+		},
+		{
+			name:     "by tag",
+			query:    service.SearchQuery{Query: "c", Tag: pricingTag},
+			expected: []string{conceptURI},
+		},
+		{
+			name:     "by tag slug",
+			query:    service.SearchQuery{Query: "c", Tag: "pricing"},
+			expected: []string{conceptURI},
+		},
+		{
+			name:     "limit",
+			query:    service.SearchQuery{Query: "discount", Limit: 1},
+			expected: []string{conceptURI},
+		},
+	}
+
+	svc, _ := newIndexedService(t)
+	populateSearch(t, svc)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			hits, err := svc.Search(tc.query)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, hitURIs(hits))
+		})
+	}
+}
+
+func TestSearch_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query service.SearchQuery
+		code  string
+	}{
+		{"empty query", service.SearchQuery{}, outputs.ErrValidationFailed},
+		{"tag entity type", service.SearchQuery{Query: "x", EntityTypes: []string{model.EntityTypeTag}}, outputs.ErrValidationFailed},
+		{"context is not a context", service.SearchQuery{Query: "x", Context: domainURI}, outputs.ErrTypeMismatch},
+	}
+
+	svc := newService(t)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.Search(tc.query)
+			requireAppError(t, err, tc.code)
+		})
+	}
+}
+
+func TestSearchText(t *testing.T) {
+	// given
+	svc, _ := newIndexedService(t)
+	populateSearch(t, svc)
+	c, err := svc.GetConcept(conceptURI)
+	require.NoError(t, err)
+	c.Body = "Discounts stack with free shipping.\n"
+	_, err = svc.UpdateConcept(c)
+	require.NoError(t, err)
+	// when
+	hits, err := svc.SearchText(service.TextSearchQuery{Query: "shipping"})
+	// then
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{shippingURI, freeShippingURI, conceptURI}, textHitURIs(hits))
+	assert.Greater(t, hits[0].Score, hits[len(hits)-1].Score)
+
+	// when — filtered by domain
+	hits, err = svc.SearchText(service.TextSearchQuery{Query: "shipping", Domain: domainURI})
+	// then
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, "Discounts stack with free **shipping**.\n", hits[0].Snippet)
+
+	// when — deleted
+	require.NoError(t, svc.DeleteConcept(conceptURI))
+	hits, err = svc.SearchText(service.TextSearchQuery{Query: "stack"})
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+}
+
+func TestSearchText_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query service.TextSearchQuery
+		code  string
+	}{
+		{"empty query", service.TextSearchQuery{Query: " "}, outputs.ErrValidationFailed},
+		{"tag entity type", service.TextSearchQuery{Query: "x", EntityTypes: []string{model.EntityTypeTag}}, outputs.ErrValidationFailed},
+		{"domain is not a domain", service.TextSearchQuery{Query: "x", Domain: contextURI}, outputs.ErrTypeMismatch},
+	}
+
+	svc, _ := newIndexedService(t)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.SearchText(tc.query)
+			requireAppError(t, err, tc.code)
+		})
+	}
+}
+
+func TestSearchText_RequiresIndex(t *testing.T) {
+	// given
+	svc := newService(t)
+	// when
+	_, err := svc.SearchText(service.TextSearchQuery{Query: "x"})
+	// then
+	assert.Error(t, err)
+}
@@ -0,0 +1,319 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/git"
+	"github.com/jjmrocha/knowledge-mcp/internal/index"
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+	"github.com/jjmrocha/knowledge-mcp/internal/validation"
+)
+
+// Service implements the knowledge store operations on top of the storage
+// layer, enforcing the rules that keep entities consistent with each other.
+type Service struct {
+	rootDir   string
+	files     store
+	mu        sync.Mutex
+	clock     func() time.Time
+	validator *validation.Validator
+	// index, when set, is kept in sync with every write and answers
+	// listing and reverse lookup queries.
+	index *index.Index
+	// git, when set, receives a commit for every file written or removed.
+	git *git.Repo
+	// seen is the state of the entity files on the previous SyncIndex, and
+	// syncMu serializes the calls to SyncIndex that compare against it.
+	seen   map[string]storage.FileState
+	syncMu sync.Mutex
+	// snapshots holds back the history of a transaction being committed.
+	snapshots *pendingSnapshots
+	// storeLocked is set on transactional copies, which only run while the
+	// service that created them holds the store lock.
+	storeLocked bool
+	// batch is set on the transactional copy of the service used to apply
+	// a batch of operations.
+	batch *batchState
+}
+
+// Option configures optional Service features.
+type Option func(*Service)
+
+// WithIndex makes the service keep idx in sync with every write and use it
+// for listing and reverse lookups.
+func WithIndex(idx *index.Index) Option {
+	return func(s *Service) {
+		s.index = idx
+	}
+}
+
+func New(rootDir string, opts ...Option) *Service {
+	snapshots := &pendingSnapshots{}
+	s := &Service{
+		rootDir:   rootDir,
+		files:     historyStore{store: diskStore{rootDir: rootDir}, rootDir: rootDir, pending: snapshots},
+		clock:     func() time.Time { return time.Now().UTC().Truncate(time.Second) },
+		snapshots: snapshots,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.index != nil {
+		s.files = indexedStore{store: s.files, index: s.index}
+	}
+
+	if s.git != nil {
+		s.files = gitStore{store: s.files, rootDir: rootDir, repo: s.git}
+	}
+
+	s.validator = validation.New(storeLookup{s: s})
+	return s
+}
+
+// transaction returns a copy of the service that writes to overlay, so that
+// a set of changes can be checked as a whole before overlay is committed.
+func (s *Service) transaction(overlay *overlayStore) *Service {
+	tx := &Service{
+		rootDir:     s.rootDir,
+		files:       overlay,
+		clock:       s.clock,
+		storeLocked: true,
+	}
+	tx.validator = validation.New(storeLookup{s: tx})
+	return tx
+}
+
+// atomically runs apply on a transaction of the service and commits every
+// change it made as a whole, so that a failure leaves the store untouched.
+// A service that is already a transaction runs apply itself, leaving the
+// commit to whoever created it.
+func (s *Service) atomically(apply func(tx *Service) error) error {
+	if s.storeLocked {
+		return apply(s)
+	}
+
+	overlay := newOverlayStore(s.files)
+
+	if err := apply(s.transaction(overlay)); err != nil {
+		return err
+	}
+
+	return s.commit(overlay)
+}
+
+func (s *Service) RootDir() string {
+	return s.rootDir
+}
+
+func (s *Service) exists(u *uri.URI) bool {
+	return s.files.exists(u)
+}
+
+func (s *Service) readFile(u *uri.URI) (string, error) {
+	content, err := s.files.read(u)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", notFoundError(u.Raw)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", u, err)
+	}
+
+	return string(content), nil
+}
+
+func (s *Service) writeFile(u *uri.URI, content string) error {
+	if err := s.files.write(u, []byte(content)); err != nil {
+		return fmt.Errorf("failed to write %s: %w", u, err)
+	}
+
+	return nil
+}
+
+func (s *Service) deleteFile(u *uri.URI) error {
+	if !s.exists(u) {
+		return notFoundError(u.Raw)
+	}
+
+	if err := s.files.remove(u); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", u, err)
+	}
+
+	return nil
+}
+
+// checkEntity runs every check that applies before entity is written at u.
+// Inside a batch the checks are deferred until all operations are applied,
+// so that entities may reference others created later in the same batch.
+func (s *Service) checkEntity(u *uri.URI, entity any) error {
+	if s.batch != nil {
+		s.batch.wrote(u)
+		return nil
+	}
+
+	return s.runChecks(u, entity)
+}
+
+func (s *Service) runChecks(u *uri.URI, entity any) error {
+	if err := s.checkParent(u); err != nil {
+		return err
+	}
+
+	switch e := entity.(type) {
+	case *model.Tag:
+		return s.checkTag(e)
+	case *model.RelationType:
+		return s.checkRelationType(e)
+	default:
+		return s.validator.Validate(entity)
+	}
+}
+
+// checkUnused verifies that no entity still uses the tag or relation type at
+// u before it is deleted. Inside a batch the check is deferred like
+// checkEntity.
+func (s *Service) checkUnused(u *uri.URI) error {
+	if s.batch != nil {
+		s.batch.removed(u)
+		return nil
+	}
+
+	return s.runUnusedCheck(u)
+}
+
+func (s *Service) runUnusedCheck(u *uri.URI) error {
+	if u.Entity == model.EntityTypeTag {
+		referencedBy, err := s.entitiesTagged(u.Raw)
+		if err != nil {
+			return err
+		}
+
+		if len(referencedBy) > 0 {
+			return tagInUseError(u.Raw, referencedBy)
+		}
+
+		return nil
+	}
+
+	referencedBy, err := s.entitiesRelatedBy(u.Raw)
+	if err != nil {
+		return err
+	}
+
+	if len(referencedBy) > 0 {
+		return relationInUseError(u.Raw, referencedBy)
+	}
+
+	return nil
+}
+
+// checkParent verifies that the context or domain containing u exists.
+func (s *Service) checkParent(u *uri.URI) error {
+	if u.Context == nil {
+		return nil
+	}
+
+	parentRaw, err := u.ParentURI()
+	if err != nil {
+		return err
+	}
+
+	parent, err := uri.Parse(parentRaw)
+	if err != nil {
+		return fmt.Errorf("invalid parent URI %s: %w", parentRaw, err)
+	}
+
+	if !s.exists(parent) {
+		return parentNotFoundError(u.Raw, parentRaw)
+	}
+
+	return nil
+}
+
+// containedEntities lists the URIs of every entity stored inside a context
+// or domain, excluding the entity itself.
+func (s *Service) containedEntities(u *uri.URI) ([]string, error) {
+	all, err := s.files.list()
+	if err != nil {
+		return nil, err
+	}
+
+	var contained []string
+
+	for _, other := range all {
+		if contains(u, other) {
+			contained = append(contained, other.Raw)
+		}
+	}
+
+	sort.Strings(contained)
+	return contained, nil
+}
+
+// deleteContainer deletes a context or domain and everything inside it,
+// returning the URIs of the contained entities that were removed with it.
+func (s *Service) deleteContainer(u *uri.URI, confirm bool) ([]string, error) {
+	if !s.exists(u) {
+		return nil, notFoundError(u.Raw)
+	}
+
+	contained, err := s.containedEntities(u)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(contained) > 0 && !confirm {
+		return nil, confirmationRequiredError(u.Raw, contained)
+	}
+
+	if err := s.deleteFile(u); err != nil {
+		return nil, err
+	}
+
+	return contained, nil
+}
+
+func parseURI(raw, entityType string) (*uri.URI, error) {
+	u, err := uri.Parse(raw)
+	if err != nil {
+		return nil, invalidURIError(raw, err)
+	}
+
+	if u.Entity != entityType {
+		return nil, uriTypeMismatchError(raw, entityType, u.Entity)
+	}
+
+	return u, nil
+}
+
+// lock serializes read-modify-write cycles on the store: s.mu between the
+// goroutines of this process and the store lock between the processes
+// sharing the root directory. Version checks must run while it is held.
+func (s *Service) lock() (func(), error) {
+	s.mu.Lock()
+
+	if s.storeLocked {
+		return s.mu.Unlock, nil
+	}
+
+	storeLock, err := storage.LockStore(s.rootDir)
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	return func() {
+		// Closing the lock file releases the lock even if unlocking fails.
+		_ = storeLock.Unlock()
+		s.mu.Unlock()
+	}, nil
+}
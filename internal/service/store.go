@@ -0,0 +1,111 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/index"
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// store is where the service reads and writes entity files. Removing a
+// context or domain removes everything inside it.
+type store interface {
+	exists(u *uri.URI) bool
+	read(u *uri.URI) ([]byte, error)
+	write(u *uri.URI, content []byte) error
+	remove(u *uri.URI) error
+	list() ([]*uri.URI, error)
+}
+
+// diskStore is the store backed by the storage package.
+type diskStore struct {
+	rootDir string
+}
+
+func (d diskStore) exists(u *uri.URI) bool {
+	return storage.FileExists(d.rootDir, u)
+}
+
+func (d diskStore) read(u *uri.URI) ([]byte, error) {
+	return storage.ReadFile(d.rootDir, u)
+}
+
+func (d diskStore) write(u *uri.URI, content []byte) error {
+	return storage.SaveFile(d.rootDir, u, content)
+}
+
+func (d diskStore) remove(u *uri.URI) error {
+	return storage.DeleteFile(d.rootDir, u)
+}
+
+func (d diskStore) list() ([]*uri.URI, error) {
+	var found []*uri.URI
+
+	err := storage.FindFiles(d.rootDir, true, func(fileName string) {
+		if u, err := storage.URIFromFileName(d.rootDir, fileName); err == nil {
+			found = append(found, u)
+		}
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list entities: %w", err)
+	}
+
+	return found, nil
+}
+
+// indexedStore keeps an index in sync with the writes to its store and
+// lists entities from the index instead of walking the directory tree.
+type indexedStore struct {
+	store
+	index *index.Index
+}
+
+func (d indexedStore) write(u *uri.URI, content []byte) error {
+	if err := d.store.write(u, content); err != nil {
+		return err
+	}
+
+	return d.index.Update(u, content)
+}
+
+func (d indexedStore) remove(u *uri.URI) error {
+	if err := d.store.remove(u); err != nil {
+		return err
+	}
+
+	return d.index.Remove(u)
+}
+
+func (d indexedStore) list() ([]*uri.URI, error) {
+	raws, err := d.index.URIs()
+	if err != nil {
+		return nil, err
+	}
+
+	found := make([]*uri.URI, 0, len(raws))
+
+	for _, raw := range raws {
+		if u, err := uri.Parse(raw); err == nil {
+			found = append(found, u)
+		}
+	}
+
+	return found, nil
+}
+
+// contains reports whether u is stored inside the context or domain
+// container, and is therefore removed with it.
+func contains(container, u *uri.URI) bool {
+	switch container.Entity {
+	case model.EntityTypeContext:
+		return u.Context != nil && *u.Context == container.Slug
+	case model.EntityTypeDomain:
+		return u.Domain != nil && *u.Domain == container.Slug && *u.Context == *container.Context
+	default:
+		return false
+	}
+}
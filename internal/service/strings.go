@@ -0,0 +1,62 @@
+package service
+
+import "slices"
+
+// uniqueStrings returns values without duplicates, keeping the first
+// occurrence of each. A nil slice stays nil.
+func uniqueStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+
+	unique := make([]string, 0, len(values))
+
+	for _, v := range values {
+		if !slices.Contains(unique, v) {
+			unique = append(unique, v)
+		}
+	}
+
+	return unique
+}
+
+// addString appends value to *values unless already present and reports
+// whether *values changed.
+func addString(values *[]string, value string) bool {
+	if slices.Contains(*values, value) {
+		return false
+	}
+
+	*values = append(*values, value)
+	return true
+}
+
+// removeString removes every occurrence of value from *values and reports
+// whether *values changed.
+func removeString(values *[]string, value string) bool {
+	remaining := slices.DeleteFunc(slices.Clone(*values), func(v string) bool { return v == value })
+	if len(remaining) == len(*values) {
+		return false
+	}
+
+	*values = remaining
+	return true
+}
+
+// sameElements reports whether a and b hold the same values, ignoring order
+// and duplicates.
+func sameElements(a, b []string) bool {
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			return false
+		}
+	}
+
+	for _, v := range b {
+		if !slices.Contains(a, v) {
+			return false
+		}
+	}
+
+	return true
+}
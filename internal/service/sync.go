@@ -0,0 +1,87 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// SyncIndex brings the index, when the service has one, up to date with
+// entity files changed outside the service, such as edits in an editor or a
+// git pull. It returns the URIs of the entity files created, changed or
+// removed since the previous call by anyone: this service, another server
+// sharing the root directory, or an outside edit. The files are walked
+// without holding the store lock, which is only taken to update the index.
+func (s *Service) SyncIndex() ([]string, error) {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	files, err := storage.ScanEntityFiles(s.rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan entity files: %w", err)
+	}
+
+	changed := make(map[string]bool)
+
+	if s.index != nil {
+		synced, err := s.syncIndex(files)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, raw := range synced {
+			changed[raw] = true
+		}
+	}
+
+	// The index is shared with the other servers, which keep it up to date
+	// with their own writes, so the files are also compared with what this
+	// service saw on the previous call.
+	if s.seen != nil {
+		for path, state := range files {
+			if seen, ok := s.seen[path]; !ok || !seen.Same(state) {
+				changed[state.URI.Raw] = true
+			}
+		}
+
+		for path, state := range s.seen {
+			if _, ok := files[path]; !ok {
+				changed[state.URI.Raw] = true
+			}
+		}
+	}
+
+	s.seen = files
+
+	uris := make([]string, 0, len(changed))
+	for raw := range changed {
+		uris = append(uris, raw)
+	}
+
+	sort.Strings(uris)
+	return uris, nil
+}
+
+// syncIndex applies files to the index under the store lock.
+func (s *Service) syncIndex(files map[string]storage.FileState) ([]string, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return s.index.Sync(files)
+}
+
+// Markdown returns the stored file of the entity at rawURI, following
+// redirects from retired URIs.
+func (s *Service) Markdown(rawURI string) (string, error) {
+	u, err := uri.Parse(rawURI)
+	if err != nil {
+		return "", invalidURIError(rawURI, err)
+	}
+
+	return s.readFile(s.followRedirect(u))
+}
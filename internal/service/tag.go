@@ -0,0 +1,416 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// contentEntities are the entity types that carry tags and relations.
+var contentEntities = []string{
+	model.EntityTypeContext,
+	model.EntityTypeDomain,
+	model.EntityTypeConcept,
+}
+
+// GetTag reads the tag at rawURI, following the redirect left
+// behind if it was moved.
+func (s *Service) GetTag(rawURI string) (*model.Tag, error) {
+	u, err := parseURI(rawURI, model.EntityTypeTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.loadTag(s.followRedirect(u))
+}
+
+// CreateTag stores a new tag at version 1 and adds the matching back-links
+// to every tag listed in its Broader and Narrower lists. The tag and its
+// back-links are written together or not at all.
+func (s *Service) CreateTag(t *model.Tag) (*model.Tag, error) {
+	u, err := parseURI(t.URI, model.EntityTypeTag)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if s.exists(u) {
+		return nil, alreadyExistsError(u.Raw)
+	}
+
+	created := s.normalizeTag(t)
+
+	if err := s.checkEntity(u, created); err != nil {
+		return nil, err
+	}
+
+	now := s.clock()
+	created.Entity = model.EntityTypeTag
+	created.Schema = model.SchemaVersion
+	created.Version = 1
+	created.Created = now
+	created.LastUpdate = now
+
+	err = s.atomically(func(tx *Service) error {
+		if err := tx.saveTag(u, created); err != nil {
+			return err
+		}
+
+		return tx.syncTagLinks(created, nil, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateTag replaces the stored tag, provided t.Version still matches the
+// version on disk, and keeps the Broader/Narrower lists of the tags on the
+// other end of every added or removed link consistent. The tag and the
+// linked tags are written together or not at all.
+func (s *Service) UpdateTag(t *model.Tag) (*model.Tag, error) {
+	u, err := parseURI(t.URI, model.EntityTypeTag)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	current, err := s.loadTag(u)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.Version != t.Version {
+		return nil, versionConflictError(u.Raw, t.Version, current.Version)
+	}
+
+	updated := s.normalizeTag(t)
+
+	if err := s.checkEntity(u, updated); err != nil {
+		return nil, err
+	}
+
+	now := s.clock()
+	updated.Entity = model.EntityTypeTag
+	updated.Schema = model.SchemaVersion
+	updated.Version = current.Version + 1
+	updated.Created = current.Created
+	updated.LastUpdate = now
+
+	err = s.atomically(func(tx *Service) error {
+		if err := tx.saveTag(u, updated); err != nil {
+			return err
+		}
+
+		return tx.syncTagLinks(updated, current, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteTag removes a tag that is no longer used by any entity, together
+// with the links other tags hold to it, as a whole.
+func (s *Service) DeleteTag(rawURI string) error {
+	u, err := parseURI(rawURI, model.EntityTypeTag)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	current, err := s.loadTag(u)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkUnused(u); err != nil {
+		return err
+	}
+
+	return s.atomically(func(tx *Service) error {
+		if err := tx.deleteFile(u); err != nil {
+			return err
+		}
+
+		return tx.syncTagLinks(&model.Tag{URI: u.Raw}, current, s.clock())
+	})
+}
+
+// normalizeTag returns a copy of t with default allowed entities and with
+// hierarchy links resolved to full URIs and without duplicates.
+func (s *Service) normalizeTag(t *model.Tag) *model.Tag {
+	normalized := *t
+
+	if normalized.AllowedEntities == nil {
+		normalized.AllowedEntities = slices.Clone(contentEntities)
+	}
+
+	s.validator.Resolve(&normalized)
+	normalized.Broader = uniqueStrings(normalized.Broader)
+	normalized.Narrower = uniqueStrings(normalized.Narrower)
+	return &normalized
+}
+
+// checkTag validates allowed entities and hierarchy links of t, including
+// that the links would not introduce a loop in the tag hierarchy.
+func (s *Service) checkTag(t *model.Tag) error {
+	for _, entityType := range t.AllowedEntities {
+		if !slices.Contains(contentEntities, entityType) {
+			return validationError(t.URI, fmt.Sprintf("entity type %q cannot be tagged", entityType), map[string]any{
+				"field":   "allowed_entities",
+				"value":   entityType,
+				"allowed": contentEntities,
+			})
+		}
+	}
+
+	if slices.Contains(t.Broader, t.URI) || slices.Contains(t.Narrower, t.URI) {
+		return tagCycleError(t.URI, []string{t.URI, t.URI})
+	}
+
+	if err := s.validator.Validate(t); err != nil {
+		return err
+	}
+
+	return s.checkTagCycle(t)
+}
+
+// checkTagCycle looks for a path from t back to itself following broader
+// links, with t's own links replacing whatever is stored for it.
+func (s *Service) checkTagCycle(t *model.Tag) error {
+	tags, err := s.allTags()
+	if err != nil {
+		return err
+	}
+
+	broader := make(map[string][]string)
+
+	for _, other := range tags {
+		if other.URI == t.URI {
+			continue
+		}
+
+		for _, b := range other.Broader {
+			if b != t.URI {
+				broader[other.URI] = append(broader[other.URI], b)
+			}
+		}
+
+		for _, n := range other.Narrower {
+			if n != t.URI {
+				broader[n] = append(broader[n], other.URI)
+			}
+		}
+	}
+
+	broader[t.URI] = append(broader[t.URI], t.Broader...)
+
+	for _, n := range t.Narrower {
+		broader[n] = append(broader[n], t.URI)
+	}
+
+	if cycle := findCycle(broader, t.URI); cycle != nil {
+		return tagCycleError(t.URI, cycle)
+	}
+
+	return nil
+}
+
+// findCycle returns a path start → … → start in graph, or nil if none exists.
+func findCycle(graph map[string][]string, start string) []string {
+	visited := make(map[string]bool)
+
+	var visit func(node string, path []string) []string
+	visit = func(node string, path []string) []string {
+		for _, next := range graph[node] {
+			if next == start {
+				return append(slices.Clone(path), next)
+			}
+
+			if visited[next] {
+				continue
+			}
+
+			visited[next] = true
+
+			if cycle := visit(next, append(path, next)); cycle != nil {
+				return cycle
+			}
+		}
+
+		return nil
+	}
+
+	return visit(start, []string{start})
+}
+
+// syncTagLinks updates the tags on the other end of t's hierarchy links so
+// that every broader tag lists t as narrower and vice versa. previous is the
+// stored state of t before the change, or nil for a new tag.
+func (s *Service) syncTagLinks(t, previous *model.Tag, now time.Time) error {
+	var previousBroader, previousNarrower []string
+
+	if previous != nil {
+		previousBroader = previous.Broader
+		previousNarrower = previous.Narrower
+	}
+
+	for _, b := range t.Broader {
+		if !slices.Contains(previousBroader, b) {
+			if err := s.updateLinkedTag(b, now, func(linked *model.Tag) bool {
+				return addString(&linked.Narrower, t.URI)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, b := range previousBroader {
+		if !slices.Contains(t.Broader, b) {
+			if err := s.updateLinkedTag(b, now, func(linked *model.Tag) bool {
+				return removeString(&linked.Narrower, t.URI)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, n := range t.Narrower {
+		if !slices.Contains(previousNarrower, n) {
+			if err := s.updateLinkedTag(n, now, func(linked *model.Tag) bool {
+				return addString(&linked.Broader, t.URI)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, n := range previousNarrower {
+		if !slices.Contains(t.Narrower, n) {
+			if err := s.updateLinkedTag(n, now, func(linked *model.Tag) bool {
+				return removeString(&linked.Broader, t.URI)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// updateLinkedTag applies change to the tag at rawURI and saves it with a
+// new version if change reports a modification. Dangling links are skipped.
+func (s *Service) updateLinkedTag(rawURI string, now time.Time, change func(*model.Tag) bool) error {
+	u, parseErr := uri.Parse(rawURI)
+	if parseErr != nil || !s.exists(u) {
+		return nil
+	}
+
+	linked, err := s.loadTag(u)
+	if err != nil {
+		return err
+	}
+
+	if !change(linked) {
+		return nil
+	}
+
+	linked.Version++
+	linked.LastUpdate = now
+	return s.saveTag(u, linked)
+}
+
+func (s *Service) allTags() ([]*model.Tag, error) {
+	uris, err := s.listURIs(model.EntityTypeTag)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]*model.Tag, 0, len(uris))
+
+	for _, u := range uris {
+		t, err := s.loadTag(u)
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, t)
+	}
+
+	return tags, nil
+}
+
+// entitiesTagged returns the URIs of the entities whose Tags include tagURI.
+func (s *Service) entitiesTagged(tagURI string) ([]string, error) {
+	if s.index != nil {
+		return s.index.Tagged(tagURI)
+	}
+
+	uris, err := s.listURIs(contentEntities...)
+	if err != nil {
+		return nil, err
+	}
+
+	var tagged []string
+
+	for _, u := range uris {
+		e, err := s.loadEntity(u)
+		if err != nil {
+			return nil, err
+		}
+
+		if slices.Contains(entityTags(e), tagURI) {
+			tagged = append(tagged, u.Raw)
+		}
+	}
+
+	return tagged, nil
+}
+
+func (s *Service) loadTag(u *uri.URI) (*model.Tag, error) {
+	content, err := s.readFile(u)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := model.ParseTag(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", u, err)
+	}
+
+	if err := checkSchema(u.Raw, t.Schema); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (s *Service) saveTag(u *uri.URI, t *model.Tag) error {
+	content, err := model.EncodeTag(t)
+	if err != nil {
+		return err
+	}
+
+	return s.writeFile(u, content)
+}
@@ -0,0 +1,210 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+	"github.com/jjmrocha/knowledge-mcp/internal/validation"
+)
+
+const (
+	pricingTag  = "scio://tags/pricing"
+	discountTag = "scio://tags/discount"
+	promoTag    = "scio://tags/promotion"
+)
+
+func createTag(t *testing.T, svc *service.Service, tag *model.Tag) *model.Tag {
+	t.Helper()
+
+	created, err := svc.CreateTag(tag)
+	require.NoError(t, err)
+	return created
+}
+
+func getTag(t *testing.T, svc *service.Service, rawURI string) *model.Tag {
+	t.Helper()
+
+	tag, err := svc.GetTag(rawURI)
+	require.NoError(t, err)
+	return tag
+}
+
+func TestCreateTag(t *testing.T) {
+	// given
+	svc := newService(t)
+	// when
+	tag := createTag(t, svc, &model.Tag{URI: pricingTag})
+	// then
+	assert.Equal(t, model.EntityTypeTag, tag.Entity)
+	assert.Equal(t, 1, tag.Version)
+	assert.Equal(t, []string{"context", "domain", "concept"}, tag.AllowedEntities)
+	assert.Equal(t, tag.AllowedEntities, getTag(t, svc, pricingTag).AllowedEntities)
+}
+
+func TestCreateTag_ContextScopedRequiresContext(t *testing.T) {
+	// given
+	svc := newService(t)
+	// when
+	_, err := svc.CreateTag(&model.Tag{URI: contextURI + "/tags/local"})
+	// then
+	requireAppError(t, err, outputs.ErrParentNotFound)
+}
+
+func TestCreateTag_InvalidAllowedEntity(t *testing.T) {
+	// given
+	svc := newService(t)
+	// when
+	_, err := svc.CreateTag(&model.Tag{URI: pricingTag, AllowedEntities: []string{"relation"}})
+	// then
+	requireAppError(t, err, outputs.ErrValidationFailed)
+}
+
+func TestCreateTag_BroaderNotFound(t *testing.T) {
+	// given
+	svc := newService(t)
+	// when
+	_, err := svc.CreateTag(&model.Tag{URI: discountTag, Broader: []string{pricingTag}})
+	// then
+	appErr := requireAppError(t, err, outputs.ErrTagNotFound)
+	violations := appErr.Details["violations"].([]validation.Violation)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "broader[0]", violations[0].Field)
+	assert.Equal(t, pricingTag, violations[0].Value)
+}
+
+func TestCreateTag_AddsBackLinks(t *testing.T) {
+	// given
+	svc := newService(t)
+	createTag(t, svc, &model.Tag{URI: pricingTag})
+	createTag(t, svc, &model.Tag{URI: promoTag})
+	// when
+	createTag(t, svc, &model.Tag{URI: discountTag, Broader: []string{pricingTag}, Narrower: []string{promoTag}})
+	// then
+	pricing := getTag(t, svc, pricingTag)
+	assert.Equal(t, []string{discountTag}, pricing.Narrower)
+	assert.Equal(t, 2, pricing.Version)
+
+	promo := getTag(t, svc, promoTag)
+	assert.Equal(t, []string{discountTag}, promo.Broader)
+	assert.Equal(t, 2, promo.Version)
+}
+
+func TestCreateTag_BackLinkFailureRollsBack(t *testing.T) {
+	// given
+	svc, _ := newIndexedService(t)
+	createTag(t, svc, &model.Tag{URI: pricingTag})
+	failIndexing(t, svc.RootDir(), pricingTag)
+	// when
+	_, err := svc.CreateTag(&model.Tag{URI: discountTag, Broader: []string{pricingTag}})
+	// then
+	require.Error(t, err)
+	_, err = svc.GetTag(discountTag)
+	requireAppError(t, err, outputs.ErrNotFound)
+	pricing := getTag(t, svc, pricingTag)
+	assert.Empty(t, pricing.Narrower)
+	assert.Equal(t, 1, pricing.Version)
+}
+
+func TestUpdateTag_SyncsRemovedAndAddedLinks(t *testing.T) {
+	// given
+	svc := newService(t)
+	createTag(t, svc, &model.Tag{URI: pricingTag})
+	createTag(t, svc, &model.Tag{URI: promoTag})
+	discount := createTag(t, svc, &model.Tag{URI: discountTag, Broader: []string{pricingTag}})
+	// when
+	updated, err := svc.UpdateTag(&model.Tag{URI: discountTag, Broader: []string{promoTag}, Version: discount.Version})
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Version)
+	assert.Empty(t, getTag(t, svc, pricingTag).Narrower)
+	assert.Equal(t, []string{discountTag}, getTag(t, svc, promoTag).Narrower)
+}
+
+func TestUpdateTag_VersionConflict(t *testing.T) {
+	// given
+	svc := newService(t)
+	createTag(t, svc, &model.Tag{URI: pricingTag})
+	// when
+	_, err := svc.UpdateTag(&model.Tag{URI: pricingTag, Version: 4})
+	// then
+	requireAppError(t, err, outputs.ErrVersionConflict)
+}
+
+func TestUpdateTag_RejectsCycle(t *testing.T) {
+	tests := []struct {
+		name     string
+		broader  []string
+		narrower []string
+	}{
+		{"self reference", []string{pricingTag}, nil},
+		{"broader and narrower at once", []string{discountTag}, []string{discountTag}},
+		{"through the hierarchy", []string{promoTag}, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given: promotion → discount → pricing
+			svc := newService(t)
+			pricing := createTag(t, svc, &model.Tag{URI: pricingTag})
+			createTag(t, svc, &model.Tag{URI: discountTag, Broader: []string{pricingTag}})
+			createTag(t, svc, &model.Tag{URI: promoTag, Broader: []string{discountTag}})
+			pricing = getTag(t, svc, pricing.URI)
+			// when
+			_, err := svc.UpdateTag(&model.Tag{
+				URI:      pricingTag,
+				Broader:  tc.broader,
+				Narrower: append(tc.narrower, pricing.Narrower...),
+				Version:  pricing.Version,
+			})
+			// then
+			appErr := requireAppError(t, err, outputs.ErrTagCycle)
+			assert.NotEmpty(t, appErr.Details["cycle"])
+		})
+	}
+}
+
+func TestDeleteTag_RemovesBackLinks(t *testing.T) {
+	// given
+	svc := newService(t)
+	createTag(t, svc, &model.Tag{URI: pricingTag})
+	createTag(t, svc, &model.Tag{URI: discountTag, Broader: []string{pricingTag}})
+	// when
+	err := svc.DeleteTag(discountTag)
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, getTag(t, svc, pricingTag).Narrower)
+	_, err = svc.GetTag(discountTag)
+	requireAppError(t, err, outputs.ErrNotFound)
+}
+
+func TestDeleteTag_BackLinkFailureRollsBack(t *testing.T) {
+	// given
+	svc, _ := newIndexedService(t)
+	createTag(t, svc, &model.Tag{URI: pricingTag})
+	createTag(t, svc, &model.Tag{URI: discountTag, Broader: []string{pricingTag}})
+	failIndexing(t, svc.RootDir(), pricingTag)
+	// when
+	err := svc.DeleteTag(discountTag)
+	// then
+	require.Error(t, err)
+	assert.Equal(t, []string{pricingTag}, getTag(t, svc, discountTag).Broader)
+	assert.Equal(t, []string{discountTag}, getTag(t, svc, pricingTag).Narrower)
+}
+
+func TestDeleteTag_InUse(t *testing.T) {
+	// given
+	svc := newServiceWithDomain(t)
+	createTag(t, svc, &model.Tag{URI: pricingTag})
+	_, err := svc.CreateConcept(&model.Concept{URI: conceptURI, Name: "Discount", Tags: []string{pricingTag}})
+	require.NoError(t, err)
+	// when
+	err = svc.DeleteTag(pricingTag)
+	// then
+	appErr := requireAppError(t, err, outputs.ErrTagInUse)
+	assert.Equal(t, []string{conceptURI}, appErr.Details["referenced_by"])
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change of
+// a unified diff.
+const diffContext = 3
+
+type diffLine struct {
+	op   byte // ' ' kept, '-' removed, '+' added
+	text string
+}
+
+// unifiedDiff returns the changes from the from text to the to text in the
+// unified diff format, labelling the sides with fromName and toName, or an
+// empty string if the texts are equal.
+func unifiedDiff(fromName, toName, from, to string) string {
+	if from == to {
+		return ""
+	}
+
+	lines := diffLines(splitLines(from), splitLines(to))
+
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "--- %s\n+++ %s\n", fromName, toName)
+
+	for _, h := range hunks(lines) {
+		writeHunk(&builder, lines, h[0], h[1])
+	}
+
+	return builder.String()
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines aligns a and b on their longest common subsequence of lines.
+func diffLines(a, b []string) []diffLine {
+	// common[i][j] is the length of the longest common subsequence of
+	// a[i:] and b[j:].
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]diffLine, 0, len(a)+len(b))
+	i, j := 0, 0
+
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, diffLine{op: ' ', text: a[i]})
+			i++
+			j++
+		case common[i+1][j] >= common[i][j+1]:
+			lines = append(lines, diffLine{op: '-', text: a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{op: '+', text: b[j]})
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{op: '-', text: a[i]})
+	}
+
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{op: '+', text: b[j]})
+	}
+
+	return lines
+}
+
+// hunks returns the [start, end) ranges of lines to print: every change with
+// its surrounding context, joining changes whose contexts touch.
+func hunks(lines []diffLine) [][2]int {
+	var ranges [][2]int
+
+	for i := 0; i < len(lines); {
+		if lines[i].op == ' ' {
+			i++
+			continue
+		}
+
+		start := max(0, i-diffContext)
+		last := i
+
+		for j := i; j < len(lines) && j-last <= 2*diffContext; j++ {
+			if lines[j].op != ' ' {
+				last = j
+			}
+		}
+
+		end := min(len(lines), last+diffContext+1)
+		ranges = append(ranges, [2]int{start, end})
+		i = end
+	}
+
+	return ranges
+}
+
+func writeHunk(builder *strings.Builder, lines []diffLine, start, end int) {
+	fromLine, toLine := 1, 1
+
+	for _, l := range lines[:start] {
+		if l.op != '+' {
+			fromLine++
+		}
+
+		if l.op != '-' {
+			toLine++
+		}
+	}
+
+	fromCount, toCount := 0, 0
+
+	for _, l := range lines[start:end] {
+		if l.op != '+' {
+			fromCount++
+		}
+
+		if l.op != '-' {
+			toCount++
+		}
+	}
+
+	// An empty side starts at the line before the hunk.
+	if fromCount == 0 {
+		fromLine--
+	}
+
+	if toCount == 0 {
+		toLine--
+	}
+
+	fmt.Fprintf(builder, "@@ -%d,%d +%d,%d @@\n", fromLine, fromCount, toLine, toCount)
+
+	for _, l := range lines[start:end] {
+		builder.WriteByte(l.op)
+		builder.WriteString(l.text)
+		builder.WriteByte('\n')
+	}
+}
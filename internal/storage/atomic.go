@@ -0,0 +1,190 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Temporary files are written next to their target as
+// ".<name>-<random>.tmp" and renamed over it once complete.
+const (
+	tempPrefix = "."
+	tempSuffix = ".tmp"
+)
+
+// writeFileAtomic replaces fileName with content so that readers, and the
+// file left behind by a crash, only ever see the old or the new content in
+// full: content is written to a temporary file in the same directory, synced
+// to disk and renamed over fileName.
+func writeFileAtomic(fileName string, content []byte) (err error) {
+	dir := filepath.Dir(fileName)
+
+	tmp, err := os.CreateTemp(dir, tempPrefix+filepath.Base(fileName)+"-*"+tempSuffix)
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(content); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if err = tmp.Chmod(filePermissions); err != nil {
+		return fmt.Errorf("failed to set permissions of temporary file: %w", err)
+	}
+
+	if err = tmp.Sync(); err != nil {
+		return fmt.Errorf("failed to sync temporary file: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), fileName); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", filepath.Base(fileName), err)
+	}
+
+	syncDir(dir)
+	return nil
+}
+
+// syncDir makes a rename in dir durable. Not every platform supports syncing
+// a directory, so failures are ignored: the rename itself already happened.
+func syncDir(dir string) {
+	d, err := os.Open(dir) //nolint:gosec
+	if err != nil {
+		return
+	}
+
+	_ = d.Sync()
+	_ = d.Close()
+}
+
+// tempTrees are the folders, directly under the root directory, that hold
+// the files written atomically, besides the redirects file in the root
+// directory itself.
+var tempTrees = []string{"contexts", "tags", "relations", HistoryDir}
+
+// isTempFile reports whether name has the exact shape of a temporary file
+// writeFileAtomic creates for a target named like target matches: the
+// target name, a dash and the random digits os.CreateTemp adds.
+func isTempFile(name string, target func(string) bool) bool {
+	rest, ok := strings.CutPrefix(name, tempPrefix)
+	if !ok {
+		return false
+	}
+
+	rest, ok = strings.CutSuffix(rest, tempSuffix)
+	if !ok {
+		return false
+	}
+
+	i := strings.LastIndexByte(rest, '-')
+	if i < 0 || i == len(rest)-1 {
+		return false
+	}
+
+	for _, r := range rest[i+1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return target(rest[:i])
+}
+
+func isEntityFileName(name string) bool {
+	return len(name) > len(".md") && strings.HasSuffix(name, ".md")
+}
+
+func isRedirectsFileName(name string) bool {
+	return name == RedirectsFile
+}
+
+// CleanTempFiles removes the temporary files left under rootDir by writes
+// that never completed, such as when the process crashed or the machine
+// slept mid-write, and returns their paths relative to rootDir. The target
+// files of those writes still hold their previous content. Only the store
+// trees and the redirects file are looked at, so files the server did not
+// write are never touched. Files modified less than minAge ago are kept, as
+// they may belong to a write still in progress.
+func CleanTempFiles(rootDir string, minAge time.Duration) ([]string, error) {
+	var removed []string
+
+	cutoff := time.Now().Add(-minAge)
+
+	remove := func(path string) error {
+		info, err := os.Stat(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to inspect temporary file: %w", err)
+		}
+
+		if info.ModTime().After(cutoff) {
+			return nil
+		}
+
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to remove temporary file: %w", err)
+		}
+
+		rel, err := filepath.Rel(rootDir, path)
+		if err != nil {
+			return err
+		}
+
+		removed = append(removed, filepath.ToSlash(rel))
+		return nil
+	}
+
+	entries, err := os.ReadDir(rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clean temporary files: %w", err)
+	}
+
+	for _, e := range entries {
+		if e.Type().IsRegular() && isTempFile(e.Name(), isRedirectsFileName) {
+			if err := remove(filepath.Join(rootDir, e.Name())); err != nil {
+				return removed, fmt.Errorf("failed to clean temporary files: %w", err)
+			}
+		}
+	}
+
+	for _, tree := range tempTrees {
+		err := filepath.WalkDir(filepath.Join(rootDir, tree), func(path string, d fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) && path == filepath.Join(rootDir, tree) {
+				return filepath.SkipDir
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if !d.Type().IsRegular() || !isTempFile(d.Name(), isEntityFileName) {
+				return nil
+			}
+
+			return remove(path)
+		})
+		if err != nil {
+			return removed, fmt.Errorf("failed to clean temporary files: %w", err)
+		}
+	}
+
+	return removed, nil
+}
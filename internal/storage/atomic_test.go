@@ -0,0 +1,111 @@
+package storage_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+func TestSaveFile_LeavesNoTemporaryFile(t *testing.T) {
+	// given
+	root := t.TempDir()
+	u := createFile(t, root, "scio://tags/business-rule")
+	// when
+	err := storage.SaveFile(root, u, []byte("updated"))
+	// then
+	require.NoError(t, err)
+	entries, err := os.ReadDir(filepath.Join(root, "tags"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "business-rule.md", entries[0].Name())
+
+	info, err := os.Stat(storage.FileName(root, u))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+}
+
+func TestSaveFile_FailedReplaceKeepsTarget(t *testing.T) {
+	// given
+	root := t.TempDir()
+	u, err := uri.Parse("scio://tags/business-rule")
+	require.NoError(t, err)
+	// a directory in place of the file cannot be replaced by a rename
+	target := storage.FileName(root, u)
+	require.NoError(t, os.MkdirAll(filepath.Join(target, "keep"), 0o755))
+	// when
+	err = storage.SaveFile(root, u, []byte("content"))
+	// then
+	assert.Error(t, err)
+	entries, err := os.ReadDir(filepath.Join(root, "tags"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.DirExists(t, filepath.Join(target, "keep"))
+}
+
+func TestCleanTempFiles(t *testing.T) {
+	// given
+	root := t.TempDir()
+	u := createFile(t, root, "scio://contexts/ecommerce/domains/pricing/concepts/discount")
+	dir := storage.FileDir(root, u)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".discount.md-123.tmp"), []byte("trunc"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".redirects.yaml-456.tmp"), []byte("trunc"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, storage.HistoryDir, "tags", "pricing"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, storage.HistoryDir, "tags", "pricing", ".1.md-789.tmp"), []byte("trunc"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.tmp"), []byte("mine"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".notes.md-draft.tmp"), []byte("mine"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".swap.tmp"), []byte("mine"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".profile-1.tmp"), []byte("mine"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "drafts"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "drafts", ".idea.md-1.tmp"), []byte("mine"), 0o600))
+	// when
+	removed, err := storage.CleanTempFiles(root, 0)
+	// then
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		".redirects.yaml-456.tmp",
+		".history/tags/pricing/.1.md-789.tmp",
+		"contexts/ecommerce/domains/pricing/.discount.md-123.tmp",
+	}, removed)
+	assert.NoFileExists(t, filepath.Join(dir, ".discount.md-123.tmp"))
+	assert.FileExists(t, filepath.Join(dir, "notes.tmp"))
+	assert.FileExists(t, filepath.Join(dir, ".notes.md-draft.tmp"))
+	assert.FileExists(t, filepath.Join(dir, ".swap.tmp"))
+	assert.FileExists(t, filepath.Join(root, ".profile-1.tmp"))
+	assert.FileExists(t, filepath.Join(root, "drafts", ".idea.md-1.tmp"))
+	assert.FileExists(t, storage.FileName(root, u))
+}
+
+func TestCleanTempFiles_Nothing(t *testing.T) {
+	// given
+	root := t.TempDir()
+	createFile(t, root, "scio://tags/business-rule")
+	// when
+	removed, err := storage.CleanTempFiles(root, 0)
+	// then
+	assert.NoError(t, err)
+	assert.Empty(t, removed)
+}
+
+func TestCleanTempFiles_KeepsRecent(t *testing.T) {
+	// given
+	root := t.TempDir()
+	u := createFile(t, root, "scio://tags/business-rule")
+	recent := filepath.Join(storage.FileDir(root, u), ".business-rule.md-1.tmp")
+	old := filepath.Join(storage.FileDir(root, u), ".business-rule.md-2.tmp")
+	require.NoError(t, os.WriteFile(recent, []byte("in progress"), 0o600))
+	require.NoError(t, os.WriteFile(old, []byte("trunc"), 0o600))
+	require.NoError(t, os.Chtimes(old, time.Now().Add(-time.Hour), time.Now().Add(-time.Hour)))
+	// when
+	removed, err := storage.CleanTempFiles(root, time.Minute)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tags/.business-rule.md-2.tmp"}, removed)
+	assert.FileExists(t, recent)
+}
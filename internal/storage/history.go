@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// HistoryDir is the hidden folder, directly under the root directory, that
+// keeps a snapshot of every version written of every entity. Snapshots of an
+// entity live in a folder mirroring its file path, one file per version.
+const HistoryDir = ".history"
+
+func snapshotDir(rootDir string, u *uri.URI) string {
+	return filepath.Join(rootDir, HistoryDir, strings.TrimSuffix(FileName("", u), ".md"))
+}
+
+func snapshotName(rootDir string, u *uri.URI, version int) string {
+	return filepath.Join(snapshotDir(rootDir, u), strconv.Itoa(version)+".md")
+}
+
+// SaveSnapshot keeps content as the given version of the entity at u.
+// Saving the content already kept for that version does nothing. A
+// different snapshot of that version, left by a file that was edited or
+// reverted outside the server, is moved aside under the first free
+// "<version>~<n>" name, which is not listed as a version, and replaced.
+func SaveSnapshot(rootDir string, u *uri.URI, version int, content []byte) error {
+	existing, err := ReadSnapshot(rootDir, u, version)
+
+	switch {
+	case err == nil && bytes.Equal(existing, content):
+		return nil
+	case err == nil:
+		if err := archiveSnapshot(rootDir, u, version); err != nil {
+			return err
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+
+	if err := os.MkdirAll(snapshotDir(rootDir, u), folderPermissions); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	return writeFileAtomic(snapshotName(rootDir, u, version), content)
+}
+
+// archiveSnapshot moves the snapshot kept for the given version of the
+// entity at u aside under the first free "<version>~<n>" name.
+func archiveSnapshot(rootDir string, u *uri.URI, version int) error {
+	name := snapshotName(rootDir, u, version)
+	base := strings.TrimSuffix(name, ".md")
+
+	for n := 1; ; n++ {
+		archive := base + "~" + strconv.Itoa(n) + ".md"
+
+		if _, err := os.Stat(archive); err == nil {
+			continue
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to archive version %d: %w", version, err)
+		}
+
+		if err := os.Rename(name, archive); err != nil {
+			return fmt.Errorf("failed to archive version %d: %w", version, err)
+		}
+
+		return nil
+	}
+}
+
+// ArchiveHistory moves the snapshots kept for the entity at u aside, next
+// to its history folder under the first free "<folder>~<n>" name, so that a
+// new entity created at u starts a history of its own. It returns the
+// archive folder relative to rootDir, or "" when nothing was kept.
+func ArchiveHistory(rootDir string, u *uri.URI) (string, error) {
+	dir := snapshotDir(rootDir, u)
+
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+
+	for n := 1; ; n++ {
+		archive := dir + "~" + strconv.Itoa(n)
+
+		if _, err := os.Stat(archive); err == nil {
+			continue
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("failed to archive history: %w", err)
+		}
+
+		if err := os.Rename(dir, archive); err != nil {
+			return "", fmt.Errorf("failed to archive history: %w", err)
+		}
+
+		rel, err := filepath.Rel(rootDir, archive)
+		if err != nil {
+			return "", err
+		}
+
+		return filepath.ToSlash(rel), nil
+	}
+}
+
+func ReadSnapshot(rootDir string, u *uri.URI, version int) ([]byte, error) {
+	return os.ReadFile(snapshotName(rootDir, u, version)) //nolint:gosec
+}
+
+// SnapshotVersions returns the versions kept for the entity at u in
+// ascending order, or nil if none were kept.
+func SnapshotVersions(rootDir string, u *uri.URI) ([]int, error) {
+	entries, err := os.ReadDir(snapshotDir(rootDir, u))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history directory: %w", err)
+	}
+
+	var versions []int
+
+	for _, e := range entries {
+		name, isMarkdown := strings.CutSuffix(e.Name(), ".md")
+		if e.IsDir() || !isMarkdown {
+			continue
+		}
+
+		if version, err := strconv.Atoi(name); err == nil {
+			versions = append(versions, version)
+		}
+	}
+
+	slices.Sort(versions)
+	return versions, nil
+}
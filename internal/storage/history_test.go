@@ -0,0 +1,105 @@
+package storage_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+func TestSnapshots(t *testing.T) {
+	// given
+	root := t.TempDir()
+	u, err := uri.Parse("scio://contexts/ecommerce/domains/pricing/concepts/discount")
+	require.NoError(t, err)
+	// when
+	versions, err := storage.SnapshotVersions(root, u)
+	// then
+	assert.NoError(t, err)
+	assert.Nil(t, versions)
+
+	// when
+	for _, version := range []int{2, 10, 1} {
+		require.NoError(t, storage.SaveSnapshot(root, u, version, []byte{byte('0' + version%10)}))
+	}
+
+	versions, err = storage.SnapshotVersions(root, u)
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 10}, versions)
+	assert.FileExists(t, filepath.Join(root, ".history", "contexts", "ecommerce", "domains", "pricing", "discount", "10.md"))
+
+	content, err := storage.ReadSnapshot(root, u, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", string(content))
+
+	_, err = storage.ReadSnapshot(root, u, 3)
+	assert.Error(t, err)
+}
+
+func TestSnapshots_NotListedAsEntities(t *testing.T) {
+	// given
+	root := t.TempDir()
+	u, err := uri.Parse("scio://tags/pricing")
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveSnapshot(root, u, 1, []byte("content")))
+	// when
+	_, err = storage.URIFromFileName(root, filepath.Join(root, ".history", "tags", "pricing", "1.md"))
+	// then
+	assert.Error(t, err)
+}
+
+func TestSaveSnapshot_ArchivesDifferentContent(t *testing.T) {
+	// given
+	root := t.TempDir()
+	u, err := uri.Parse("scio://tags/pricing")
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveSnapshot(root, u, 1, []byte("first")))
+	// when
+	sameErr := storage.SaveSnapshot(root, u, 1, []byte("first"))
+	otherErr := storage.SaveSnapshot(root, u, 1, []byte("second"))
+	// then
+	require.NoError(t, sameErr)
+	require.NoError(t, otherErr)
+	content, err := storage.ReadSnapshot(root, u, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(content))
+	archived, err := os.ReadFile(filepath.Join(root, ".history", "tags", "pricing", "1~1.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(archived))
+	versions, err := storage.SnapshotVersions(root, u)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, versions)
+}
+
+func TestArchiveHistory(t *testing.T) {
+	// given
+	root := t.TempDir()
+	u, err := uri.Parse("scio://tags/pricing")
+	require.NoError(t, err)
+	// when
+	none, err := storage.ArchiveHistory(root, u)
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	// when
+	require.NoError(t, storage.SaveSnapshot(root, u, 1, []byte("first life")))
+	first, err := storage.ArchiveHistory(root, u)
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveSnapshot(root, u, 1, []byte("second life")))
+	second, err := storage.ArchiveHistory(root, u)
+	require.NoError(t, err)
+	// then
+	assert.Equal(t, ".history/tags/pricing~1", first)
+	assert.Equal(t, ".history/tags/pricing~2", second)
+	assert.FileExists(t, filepath.Join(root, ".history", "tags", "pricing~1", "1.md"))
+	versions, err := storage.SnapshotVersions(root, u)
+	require.NoError(t, err)
+	assert.Nil(t, versions)
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LockFile is the file, directly under the root directory, that processes
+// sharing the knowledge store lock to serialize their changes.
+const LockFile = ".lock"
+
+// StoreLock is an exclusive, advisory lock on the whole knowledge store,
+// held from LockStore until Unlock. On unix systems it excludes every other
+// holder, whether in another process or in this one.
+type StoreLock struct {
+	file *os.File
+}
+
+// LockStore blocks until it holds the lock on the store at rootDir.
+func LockStore(rootDir string) (*StoreLock, error) {
+	file, err := os.OpenFile(filepath.Join(rootDir, LockFile), os.O_RDWR|os.O_CREATE, filePermissions) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	if err := lockFile(file); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to lock the store: %w", err)
+	}
+
+	return &StoreLock{file: file}, nil
+}
+
+// Unlock releases the lock.
+func (l *StoreLock) Unlock() error {
+	unlockErr := unlockFile(l.file)
+
+	if err := l.file.Close(); err != nil && unlockErr == nil {
+		return fmt.Errorf("failed to close lock file: %w", err)
+	}
+
+	if unlockErr != nil {
+		return fmt.Errorf("failed to unlock the store: %w", unlockErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+//go:build !unix
+
+package storage
+
+import "os"
+
+// Advisory file locks are only supported on unix systems. Elsewhere the lock
+// file is still created, but changes are only serialized within a process.
+
+func lockFile(*os.File) error {
+	return nil
+}
+
+func unlockFile(*os.File) error {
+	return nil
+}
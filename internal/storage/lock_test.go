@@ -0,0 +1,151 @@
+package storage_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+)
+
+const (
+	lockHelperEnv = "KNOWLEDGE_MCP_LOCK_HELPER_ROOT"
+	increments    = 50
+)
+
+// incrementCounter performs one read-modify-write cycle on the counter file
+// of rootDir under the store lock.
+func incrementCounter(rootDir string) error {
+	lock, err := storage.LockStore(rootDir)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = lock.Unlock() }()
+
+	counterFile := filepath.Join(rootDir, "counter")
+
+	content, err := os.ReadFile(counterFile) //nolint:gosec
+	if err != nil {
+		return err
+	}
+
+	count, err := strconv.Atoi(string(content))
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(counterFile, []byte(strconv.Itoa(count+1)), 0o600)
+}
+
+func newCounter(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "counter"), []byte("0"), 0o600))
+	return root
+}
+
+func readCounter(t *testing.T, root string) int {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(root, "counter")) //nolint:gosec
+	require.NoError(t, err)
+	count, err := strconv.Atoi(string(content))
+	require.NoError(t, err)
+	return count
+}
+
+// TestLockStore_HelperProcess is the body of the writer processes started
+// by TestLockStore_Processes; it does nothing when run as a test.
+func TestLockStore_HelperProcess(t *testing.T) {
+	root := os.Getenv(lockHelperEnv)
+	if root == "" {
+		t.Skip("only runs as a helper process")
+	}
+
+	for range increments {
+		require.NoError(t, incrementCounter(root))
+	}
+}
+
+func TestLockStore_Goroutines(t *testing.T) {
+	// given
+	root := newCounter(t)
+	writers := 8
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers*increments)
+	// when
+	for range writers {
+		wg.Go(func() {
+			for range increments {
+				errs <- incrementCounter(root)
+			}
+		})
+	}
+
+	wg.Wait()
+	close(errs)
+	// then
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, writers*increments, readCounter(t, root))
+}
+
+func TestLockStore_Processes(t *testing.T) {
+	// given
+	root := newCounter(t)
+	writers := 4
+	cmds := make([]*exec.Cmd, 0, writers)
+	// when
+	for range writers {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestLockStore_HelperProcess$") //nolint:gosec
+		cmd.Env = append(os.Environ(), lockHelperEnv+"="+root)
+		require.NoError(t, cmd.Start())
+		cmds = append(cmds, cmd)
+	}
+
+	// then
+	for _, cmd := range cmds {
+		require.NoError(t, cmd.Wait())
+	}
+
+	assert.Equal(t, writers*increments, readCounter(t, root))
+}
+
+func TestLockStore_Exclusive(t *testing.T) {
+	// given
+	root := t.TempDir()
+	first, err := storage.LockStore(root)
+	require.NoError(t, err)
+
+	acquired := make(chan *storage.StoreLock)
+	// when
+	go func() {
+		second, err := storage.LockStore(root)
+		assert.NoError(t, err)
+		acquired <- second
+	}()
+
+	// then
+	select {
+	case <-acquired:
+		t.Fatal("second lock acquired while the first is held")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.NoError(t, first.Unlock())
+	second := <-acquired
+	require.NoError(t, second.Unlock())
+	assert.FileExists(t, filepath.Join(root, storage.LockFile))
+}
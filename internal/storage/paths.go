@@ -1,7 +1,9 @@
 package storage
 
 import (
+	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/jjmrocha/knowledge-mcp/internal/model"
 	"github.com/jjmrocha/knowledge-mcp/internal/uri"
@@ -35,3 +37,44 @@ func FileName(rootDir string, u *uri.URI) string {
 func FileDir(rootDir string, u *uri.URI) string {
 	return filepath.Dir(FileName(rootDir, u))
 }
+
+// URIFromFileName is the inverse of FileName: it returns the URI of the
+// entity stored in fileName, or an error if the path is not an entity file.
+func URIFromFileName(rootDir, fileName string) (*uri.URI, error) {
+	rel, err := filepath.Rel(rootDir, fileName)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not inside %s: %w", fileName, rootDir, err)
+	}
+
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	last := len(parts) - 1
+
+	slug, isMarkdown := strings.CutSuffix(parts[last], ".md")
+	if !isMarkdown {
+		return nil, fmt.Errorf("%s is not an entity file", fileName)
+	}
+
+	var raw string
+
+	switch {
+	case len(parts) == 2 && (parts[0] == "tags" || parts[0] == "relations"):
+		raw = fmt.Sprintf("scio://%s/%s", parts[0], slug)
+
+	case len(parts) == 3 && parts[0] == "contexts" && parts[2] == "context.md":
+		raw = fmt.Sprintf("scio://contexts/%s", parts[1])
+
+	case len(parts) == 4 && parts[0] == "contexts" && (parts[2] == "tags" || parts[2] == "relations"):
+		raw = fmt.Sprintf("scio://contexts/%s/%s/%s", parts[1], parts[2], slug)
+
+	case len(parts) == 5 && parts[0] == "contexts" && parts[2] == "domains" && parts[4] == "domain.md":
+		raw = fmt.Sprintf("scio://contexts/%s/domains/%s", parts[1], parts[3])
+
+	case len(parts) == 5 && parts[0] == "contexts" && parts[2] == "domains":
+		raw = fmt.Sprintf("scio://contexts/%s/domains/%s/concepts/%s", parts[1], parts[3], slug)
+
+	default:
+		return nil, fmt.Errorf("%s is not an entity file", fileName)
+	}
+
+	return uri.Parse(raw)
+}
@@ -118,3 +118,86 @@ func TestFileDir(t *testing.T) {
 		})
 	}
 }
+
+func TestURIFromFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		expected string
+	}{
+		{
+			name:     "global tag",
+			fileName: "/data/knowledge/tags/business-rule.md",
+			expected: "scio://tags/business-rule",
+		},
+		{
+			name:     "context-scoped tag",
+			fileName: "/data/knowledge/contexts/ecommerce/tags/local-tag.md",
+			expected: "scio://contexts/ecommerce/tags/local-tag",
+		},
+		{
+			name:     "global relation",
+			fileName: "/data/knowledge/relations/implements.md",
+			expected: "scio://relations/implements",
+		},
+		{
+			name:     "context-scoped relation",
+			fileName: "/data/knowledge/contexts/ecommerce/relations/owns.md",
+			expected: "scio://contexts/ecommerce/relations/owns",
+		},
+		{
+			name:     "context",
+			fileName: "/data/knowledge/contexts/ecommerce/context.md",
+			expected: "scio://contexts/ecommerce",
+		},
+		{
+			name:     "domain",
+			fileName: "/data/knowledge/contexts/ecommerce/domains/business-rules/domain.md",
+			expected: "scio://contexts/ecommerce/domains/business-rules",
+		},
+		{
+			name:     "concept",
+			fileName: "/data/knowledge/contexts/ecommerce/domains/business-rules/discount-calculation.md",
+			expected: "scio://contexts/ecommerce/domains/business-rules/concepts/discount-calculation",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, err := storage.URIFromFileName(rootDir, tc.fileName)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, u.Raw)
+		})
+	}
+}
+
+func TestURIFromFileName_NotAnEntity(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+	}{
+		{"not markdown", "/data/knowledge/tags/business-rule.txt"},
+		{"stray root file", "/data/knowledge/README.md"},
+		{"unknown folder", "/data/knowledge/contexts/ecommerce/notes/todo.md"},
+		{"invalid slug", "/data/knowledge/tags/Business_Rule.md"},
+		{"outside root", "/elsewhere/tags/business-rule.md"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := storage.URIFromFileName(rootDir, tc.fileName)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestURIFromFileName_RoundTrip(t *testing.T) {
+	// given
+	u, err := uri.Parse("scio://contexts/ecommerce/domains/business-rules/concepts/discount-calculation")
+	assert.NoError(t, err)
+	// when
+	got, err := storage.URIFromFileName(rootDir, storage.FileName(rootDir, u))
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, u, got)
+}
@@ -1,7 +1,9 @@
 package storage
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 
@@ -16,13 +18,14 @@ func ensureParentDirs(rootDir string, u *uri.URI) error {
 	return os.MkdirAll(FileDir(rootDir, u), folderPermissions)
 }
 
+// SaveFile writes content as the file of the entity at u. The file is
+// replaced atomically, so a crash never leaves it truncated.
 func SaveFile(rootDir string, u *uri.URI, content []byte) error {
 	if err := ensureParentDirs(rootDir, u); err != nil {
 		return fmt.Errorf("failed to create parent directories: %w", err)
 	}
 
-	fileName := FileName(rootDir, u)
-	return os.WriteFile(fileName, content, filePermissions)
+	return writeFileAtomic(FileName(rootDir, u), content)
 }
 
 func ReadFile(rootDir string, u *uri.URI) ([]byte, error) {
@@ -30,6 +33,11 @@ func ReadFile(rootDir string, u *uri.URI) ([]byte, error) {
 	return os.ReadFile(fileName) //nolint:gosec
 }
 
+func FileExists(rootDir string, u *uri.URI) bool {
+	info, err := os.Stat(FileName(rootDir, u))
+	return err == nil && !info.IsDir()
+}
+
 func DeleteFile(rootDir string, u *uri.URI) error {
 	fileName := FileName(rootDir, u)
 
@@ -73,6 +81,25 @@ func removeDir(dirNme string) error {
 	return os.Remove(dirNme)
 }
 
+// RedirectsFile is the file, directly under the root directory, listing the
+// retired URIs and the URIs that replaced them.
+const RedirectsFile = "redirects.yaml"
+
+// ReadRedirects returns the content of the redirects file, or nil if there
+// is none yet.
+func ReadRedirects(rootDir string) ([]byte, error) {
+	content, err := os.ReadFile(filepath.Join(rootDir, RedirectsFile)) //nolint:gosec
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	return content, err
+}
+
+func SaveRedirects(rootDir string, content []byte) error {
+	return writeFileAtomic(filepath.Join(rootDir, RedirectsFile), content)
+}
+
 func InitRootDirs(rootDir string) error {
 	for _, dir := range []string{"tags", "relations", "contexts"} {
 		if err := os.MkdirAll(filepath.Join(rootDir, dir), folderPermissions); err != nil {
@@ -105,3 +132,66 @@ func FindFiles(folder string, recursive bool, handler func(filename string)) err
 
 	return nil
 }
+
+// FileState tells two versions of an entity file apart without reading it.
+type FileState struct {
+	URI     *uri.URI
+	ModTime int64
+	Size    int64
+}
+
+// Same reports whether f and other describe the same version of a file.
+func (f FileState) Same(other FileState) bool {
+	return f.ModTime == other.ModTime && f.Size == other.Size
+}
+
+// StatFile returns the state of the file at path, which stands for u.
+func StatFile(path string, u *uri.URI) (FileState, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return FileState{}, err
+	}
+
+	return FileState{URI: u, ModTime: stat.ModTime().UnixNano(), Size: stat.Size()}, nil
+}
+
+// entityTrees are the folders of the root directory that hold entity files.
+var entityTrees = []string{"tags", "relations", "contexts"}
+
+// ScanEntityFiles returns the state of every entity file under rootDir,
+// keyed by path. Files that do not stand for an entity URI are skipped, and
+// so are the ones removed while the tree is being walked.
+func ScanEntityFiles(rootDir string) (map[string]FileState, error) {
+	files := make(map[string]FileState)
+
+	for _, tree := range entityTrees {
+		var statErr error
+
+		err := FindFiles(filepath.Join(rootDir, tree), true, func(path string) {
+			u, err := URIFromFileName(rootDir, path)
+			if err != nil || statErr != nil {
+				return
+			}
+
+			state, err := StatFile(path, u)
+			if err != nil {
+				if !errors.Is(err, fs.ErrNotExist) {
+					statErr = err
+				}
+
+				return
+			}
+
+			files[path] = state
+		})
+		if err == nil {
+			err = statErr
+		}
+
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to scan %s: %w", tree, err)
+		}
+	}
+
+	return files, nil
+}
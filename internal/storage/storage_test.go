@@ -131,6 +131,23 @@ func TestReadFile_NotFound(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestFileExists(t *testing.T) {
+	// given
+	root := t.TempDir()
+	u := createFile(t, root, "scio://tags/business-rule")
+	// then
+	assert.True(t, storage.FileExists(root, u))
+}
+
+func TestFileExists_NotFound(t *testing.T) {
+	// given
+	root := t.TempDir()
+	u, err := uri.Parse("scio://tags/business-rule")
+	assert.NoError(t, err)
+	// then
+	assert.False(t, storage.FileExists(root, u))
+}
+
 func TestDeleteFile(t *testing.T) {
 	// given
 	root := t.TempDir()
@@ -269,6 +286,33 @@ func TestFindFiles_Error(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestScanEntityFiles(t *testing.T) {
+	// given
+	root := buildFindFixture(t)
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "tags", "notes.txt"), nil, 0o600))
+	assert.NoError(t, storage.SaveRedirects(root, []byte("schema: 1\n")))
+	// when
+	files, err := storage.ScanEntityFiles(root)
+	// then
+	assert.NoError(t, err)
+
+	var got []string
+	for path, state := range files {
+		got = append(got, state.URI.Raw)
+		assert.Equal(t, storage.FileName(root, state.URI), path)
+		assert.Positive(t, state.ModTime)
+		assert.Equal(t, int64(len("test file content")), state.Size)
+	}
+
+	sort.Strings(got)
+	assert.Equal(t, []string{
+		"scio://contexts/ecommerce",
+		"scio://contexts/ecommerce/domains/rules/concepts/discount",
+		"scio://tags/a",
+		"scio://tags/b",
+	}, got)
+}
+
 func createFile(t *testing.T, rootDir, fileURI string) *uri.URI {
 	t.Helper()
 	content := []byte("test file content")
@@ -278,3 +322,21 @@ func createFile(t *testing.T, rootDir, fileURI string) *uri.URI {
 	assert.NoError(t, err)
 	return u
 }
+
+func TestRedirects(t *testing.T) {
+	// given
+	root := t.TempDir()
+	// when
+	missing, err := storage.ReadRedirects(root)
+	// then
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+
+	// when
+	assert.NoError(t, storage.SaveRedirects(root, []byte("redirects: []\n")))
+	content, err := storage.ReadRedirects(root)
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "redirects: []\n", string(content))
+	assert.FileExists(t, filepath.Join(root, storage.RedirectsFile))
+}
@@ -0,0 +1,27 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+type BacklinksInput struct {
+	URI string `json:"uri" jsonschema:"URI of the entity whose incoming relations are listed"`
+}
+
+func registerBacklinkTools(server *mcp.Server, svc *service.Service) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_backlinks",
+		Description: "List every relation pointing at a URI, grouped by relation type and source entity type.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in BacklinksInput) (*mcp.CallToolResult, *service.Backlinks, error) {
+		b, err := svc.Backlinks(in.URI)
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, b, nil
+	})
+}
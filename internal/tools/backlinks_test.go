@@ -0,0 +1,43 @@
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+func TestGetBacklinksTool(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	callTool(t, session, "create_relation_type", map[string]any{"uri": "scio://relations/part-of"}, nil)
+	callTool(t, session, "create_concept", map[string]any{
+		"uri":       conceptURI,
+		"name":      "Discount",
+		"relations": []map[string]any{{"type": "part-of", "target": domainURI}},
+	}, nil)
+
+	// when
+	var backlinks service.Backlinks
+	callTool(t, session, "get_backlinks", map[string]any{"uri": domainURI}, &backlinks)
+
+	// then
+	assert.Equal(t, service.Backlinks{
+		URI:   domainURI,
+		Total: 1,
+		Groups: []service.BacklinkGroup{
+			{RelationType: "scio://relations/part-of", SourceEntity: "concept", Sources: []string{conceptURI}},
+		},
+	}, backlinks)
+}
+
+func TestGetBacklinksTool_None(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	// when
+	var backlinks service.Backlinks
+	callTool(t, session, "get_backlinks", map[string]any{"uri": domainURI}, &backlinks)
+	// then
+	assert.Empty(t, backlinks.Groups)
+}
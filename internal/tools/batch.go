@@ -0,0 +1,136 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// BatchItemInput is one operation of a batch. The entity type is taken from
+// the URI, and only the fields that apply to that type are used.
+type BatchItemInput struct {
+	Op                    string              `json:"op" jsonschema:"operation to apply: create, update or delete"`
+	URI                   string              `json:"uri" jsonschema:"URI of the context, domain, concept, tag or relation type"`
+	Version               int                 `json:"version,omitempty" jsonschema:"current version of the entity, required by update"`
+	Confirm               bool                `json:"confirm,omitempty" jsonschema:"must be true to delete a context or domain that is not empty"`
+	Name                  string              `json:"name,omitempty" jsonschema:"human readable name of a context, domain or concept"`
+	Tags                  []string            `json:"tags,omitempty" jsonschema:"tags of a context, domain or concept, as URIs or short slugs"`
+	Relations             []model.RelationRef `json:"relations,omitempty" jsonschema:"outgoing relations of a context, domain or concept; targets may be created in the same batch"`
+	Sources               []model.Source      `json:"sources,omitempty" jsonschema:"external artifacts documenting a concept"`
+	AllowedEntities       []string            `json:"allowed_entities,omitempty" jsonschema:"entity types that may use a tag; defaults to all"`
+	Broader               []string            `json:"broader,omitempty" jsonschema:"URIs of more general tags of a tag"`
+	Narrower              []string            `json:"narrower,omitempty" jsonschema:"URIs of more specific tags of a tag"`
+	InverseOf             string              `json:"inverse_of,omitempty" jsonschema:"URI of the inverse of a relation type"`
+	AllowedSourceEntities []string            `json:"allowed_source_entities,omitempty" jsonschema:"entity types allowed as source of a relation type; defaults to all"`
+	AllowedTargetEntities []string            `json:"allowed_target_entities,omitempty" jsonschema:"entity types allowed as target of a relation type; defaults to all"`
+	Transitive            bool                `json:"transitive,omitempty" jsonschema:"whether a relation type is transitive"`
+	Symmetric             bool                `json:"symmetric,omitempty" jsonschema:"whether a relation type is symmetric"`
+	Body                  string              `json:"body,omitempty" jsonschema:"markdown description of the entity"`
+}
+
+type BatchInput struct {
+	Operations []BatchItemInput `json:"operations" jsonschema:"operations to apply in order; either all of them are written or none"`
+}
+
+type BatchOutput struct {
+	Results []service.BatchResult `json:"results"`
+}
+
+func (in *BatchItemInput) operation() service.BatchOperation {
+	return service.BatchOperation{
+		Op:      in.Op,
+		URI:     in.URI,
+		Entity:  in.entity(),
+		Confirm: in.Confirm,
+	}
+}
+
+// entity builds the model entity to write, or returns nil if the URI does
+// not identify an entity, leaving the service to report it.
+func (in *BatchItemInput) entity() any {
+	u, err := uri.Parse(in.URI)
+	if err != nil {
+		return nil
+	}
+
+	switch u.Entity {
+	case model.EntityTypeTag:
+		t := (&CreateTagInput{
+			URI:             in.URI,
+			AllowedEntities: in.AllowedEntities,
+			Broader:         in.Broader,
+			Narrower:        in.Narrower,
+			Body:            in.Body,
+		}).tag()
+		t.Version = in.Version
+		return t
+	case model.EntityTypeRelation:
+		r := (&CreateRelationTypeInput{
+			URI:                   in.URI,
+			InverseOf:             in.InverseOf,
+			AllowedSourceEntities: in.AllowedSourceEntities,
+			AllowedTargetEntities: in.AllowedTargetEntities,
+			Transitive:            in.Transitive,
+			Symmetric:             in.Symmetric,
+			Body:                  in.Body,
+		}).relationType()
+		r.Version = in.Version
+		return r
+	case model.EntityTypeContext:
+		c := (&CreateContextInput{
+			URI:       in.URI,
+			Name:      in.Name,
+			Tags:      in.Tags,
+			Relations: in.Relations,
+			Body:      in.Body,
+		}).context()
+		c.Version = in.Version
+		return c
+	case model.EntityTypeDomain:
+		d := (&CreateDomainInput{
+			URI:       in.URI,
+			Name:      in.Name,
+			Tags:      in.Tags,
+			Relations: in.Relations,
+			Body:      in.Body,
+		}).domain()
+		d.Version = in.Version
+		return d
+	default:
+		c := (&CreateConceptInput{
+			URI:       in.URI,
+			Name:      in.Name,
+			Tags:      in.Tags,
+			Relations: in.Relations,
+			Sources:   in.Sources,
+			Body:      in.Body,
+		}).concept()
+		c.Version = in.Version
+		return c
+	}
+}
+
+func registerBatchTools(server *mcp.Server, svc *service.Service) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "batch",
+		Description: "Apply a list of create, update and delete operations across all entity types as one unit. " +
+			"Entities may reference others created later in the same batch. Either every operation is written or none is; " +
+			"failures are reported per operation index under BATCH_VALIDATION_FAILED.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in BatchInput) (*mcp.CallToolResult, *BatchOutput, error) {
+		ops := make([]service.BatchOperation, 0, len(in.Operations))
+		for i := range in.Operations {
+			ops = append(ops, in.Operations[i].operation())
+		}
+
+		results, err := svc.Batch(ops)
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, &BatchOutput{Results: results}, nil
+	})
+}
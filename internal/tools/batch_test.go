@@ -0,0 +1,67 @@
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/tools"
+)
+
+func TestBatchTool(t *testing.T) {
+	// given
+	session := newSession(t)
+	couponURI := domainURI + "/concepts/coupon"
+
+	// when
+	var out tools.BatchOutput
+	callTool(t, session, "batch", map[string]any{
+		"operations": []map[string]any{
+			{"op": "create", "uri": conceptURI, "name": "Discount", "relations": []map[string]any{
+				{"type": "scio://relations/related-to", "target": couponURI},
+			}},
+			{"op": "create", "uri": couponURI, "name": "Coupon", "tags": []string{"pricing"}},
+			{"op": "create", "uri": "scio://tags/pricing"},
+			{"op": "create", "uri": "scio://relations/related-to", "symmetric": true},
+			{"op": "create", "uri": domainURI, "name": "Pricing"},
+			{"op": "create", "uri": contextURI, "name": "E-commerce"},
+		},
+	}, &out)
+
+	// then
+	require.Len(t, out.Results, 6)
+	assert.Equal(t, "concept", out.Results[0].Entity)
+	assert.Equal(t, 1, out.Results[0].Version)
+
+	var coupon model.Concept
+	callTool(t, session, "get_concept", map[string]any{"uri": couponURI}, &coupon)
+	assert.Equal(t, []string{"scio://tags/pricing"}, coupon.Tags)
+}
+
+func TestBatchTool_NothingWrittenOnFailure(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+
+	// when
+	appErr := callToolError(t, session, "batch", map[string]any{
+		"operations": []map[string]any{
+			{"op": "create", "uri": conceptURI, "name": "Discount"},
+			{"op": "update", "uri": domainURI, "name": "Pricing", "version": 5},
+			{"op": "delete", "uri": "scio://nowhere"},
+		},
+	})
+
+	// then
+	assert.Equal(t, outputs.ErrBatchValidationFailed, appErr.ErrorCode)
+	failures, ok := appErr.Details["failures"].([]any)
+	require.True(t, ok)
+	require.Len(t, failures, 2)
+	assert.InDelta(t, 1, failures[0].(map[string]any)["index"], 0)
+	assert.InDelta(t, 2, failures[1].(map[string]any)["index"], 0)
+
+	appErr = callToolError(t, session, "get_concept", map[string]any{"uri": conceptURI})
+	assert.Equal(t, outputs.ErrNotFound, appErr.ErrorCode)
+}
@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+type ConceptURIInput struct {
+	URI string `json:"uri" jsonschema:"concept URI, e.g. scio://contexts/<context>/domains/<domain>/concepts/<slug>"`
+}
+
+type CreateConceptInput struct {
+	URI       string              `json:"uri" jsonschema:"concept URI, e.g. scio://contexts/<context>/domains/<domain>/concepts/<slug>"`
+	Name      string              `json:"name" jsonschema:"human readable name of the concept"`
+	Tags      []string            `json:"tags,omitempty" jsonschema:"tags classifying the concept, as URIs or short slugs resolved against the concept's context first and globally second"`
+	Relations []model.RelationRef `json:"relations,omitempty" jsonschema:"outgoing relations to other entities; relation types may be given as short slugs"`
+	Sources   []model.Source      `json:"sources,omitempty" jsonschema:"external artifacts documenting the concept"`
+	Body      string              `json:"body,omitempty" jsonschema:"markdown description of the concept"`
+}
+
+type UpdateConceptInput struct {
+	CreateConceptInput
+	Version int `json:"version" jsonschema:"current version of the concept, as returned by get_concept"`
+}
+
+func (in *CreateConceptInput) concept() *model.Concept {
+	return &model.Concept{
+		URI:       in.URI,
+		Name:      in.Name,
+		Tags:      in.Tags,
+		Relations: in.Relations,
+		Sources:   in.Sources,
+		Body:      in.Body,
+	}
+}
+
+func registerConceptTools(server *mcp.Server, svc *service.Service) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_concept",
+		Description: "Read a concept, including its current version.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in ConceptURIInput) (*mcp.CallToolResult, *model.Concept, error) {
+		c, err := svc.GetConcept(in.URI)
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, c, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_concept",
+		Description: "Create a new concept. Fails if the URI is already in use.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in CreateConceptInput) (*mcp.CallToolResult, *model.Concept, error) {
+		c, err := svc.CreateConcept(in.concept())
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, c, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_concept",
+		Description: "Replace a concept. The version must match the stored one, otherwise a VERSION_CONFLICT error is returned.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in UpdateConceptInput) (*mcp.CallToolResult, *model.Concept, error) {
+		c := in.concept()
+		c.Version = in.Version
+
+		updated, err := svc.UpdateConcept(c)
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, updated, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_concept",
+		Description: "Delete a concept.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in ConceptURIInput) (*mcp.CallToolResult, *DeleteResult, error) {
+		if err := svc.DeleteConcept(in.URI); err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, &DeleteResult{URI: in.URI, Deleted: true}, nil
+	})
+}
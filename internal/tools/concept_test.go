@@ -0,0 +1,71 @@
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/tools"
+)
+
+const conceptURI = domainURI + "/concepts/discount"
+
+func TestConceptTools_Lifecycle(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+
+	// when — create
+	var created model.Concept
+	callTool(t, session, "create_concept", map[string]any{
+		"uri":     conceptURI,
+		"name":    "Discount",
+		"sources": []map[string]any{{"type": "file", "href": "src/discount.go"}},
+		"body":    "Tiered discounts.\n",
+	}, &created)
+	// then
+	assert.Equal(t, 1, created.Version)
+	assert.Equal(t, []model.Source{{Type: "file", Href: "src/discount.go"}}, created.Sources)
+
+	// when — update
+	var updated model.Concept
+	callTool(t, session, "update_concept", map[string]any{
+		"uri":     conceptURI,
+		"name":    "Discount Calculation",
+		"version": created.Version,
+	}, &updated)
+	// then
+	assert.Equal(t, 2, updated.Version)
+
+	// when — get
+	var got model.Concept
+	callTool(t, session, "get_concept", map[string]any{"uri": conceptURI}, &got)
+	// then
+	assert.Equal(t, "Discount Calculation", got.Name)
+	assert.Equal(t, 2, got.Version)
+
+	// when — delete
+	var deleted tools.DeleteResult
+	callTool(t, session, "delete_concept", map[string]any{"uri": conceptURI}, &deleted)
+	// then
+	assert.True(t, deleted.Deleted)
+	appErr := callToolError(t, session, "get_concept", map[string]any{"uri": conceptURI})
+	assert.Equal(t, outputs.ErrNotFound, appErr.ErrorCode)
+}
+
+func TestUpdateConceptTool_VersionConflict(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	callTool(t, session, "create_concept", map[string]any{"uri": conceptURI, "name": "Discount"}, nil)
+	// when
+	appErr := callToolError(t, session, "update_concept", map[string]any{
+		"uri":     conceptURI,
+		"name":    "Stale",
+		"version": 7,
+	})
+	// then
+	assert.Equal(t, outputs.ErrVersionConflict, appErr.ErrorCode)
+	assert.InDelta(t, 1, appErr.Details["current_version"], 0)
+	assert.NotEmpty(t, appErr.SuggestedAction)
+}
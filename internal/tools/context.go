@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+type ContextURIInput struct {
+	URI string `json:"uri" jsonschema:"context URI, e.g. scio://contexts/<slug>"`
+}
+
+type CreateContextInput struct {
+	URI       string              `json:"uri" jsonschema:"context URI, e.g. scio://contexts/<slug>"`
+	Name      string              `json:"name" jsonschema:"human readable name of the context"`
+	Tags      []string            `json:"tags,omitempty" jsonschema:"tags classifying the context, as URIs or short slugs resolved against this context first and globally second"`
+	Relations []model.RelationRef `json:"relations,omitempty" jsonschema:"outgoing relations to other entities; relation types may be given as short slugs"`
+	Body      string              `json:"body,omitempty" jsonschema:"markdown description of the context"`
+}
+
+type UpdateContextInput struct {
+	CreateContextInput
+	Version int `json:"version" jsonschema:"current version of the context, as returned by get_context"`
+}
+
+type DeleteContextInput struct {
+	URI     string `json:"uri" jsonschema:"context URI, e.g. scio://contexts/<slug>"`
+	Confirm bool   `json:"confirm,omitempty" jsonschema:"must be true to delete a context that still contains domains, concepts, tags or relations"`
+}
+
+func (in *CreateContextInput) context() *model.Context {
+	return &model.Context{
+		URI:       in.URI,
+		Name:      in.Name,
+		Tags:      in.Tags,
+		Relations: in.Relations,
+		Body:      in.Body,
+	}
+}
+
+func registerContextTools(server *mcp.Server, svc *service.Service) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_context",
+		Description: "Read a context, including its current version.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in ContextURIInput) (*mcp.CallToolResult, *model.Context, error) {
+		c, err := svc.GetContext(in.URI)
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, c, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_context",
+		Description: "Create a new context. Fails if the URI is already in use.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in CreateContextInput) (*mcp.CallToolResult, *model.Context, error) {
+		c, err := svc.CreateContext(in.context())
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, c, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_context",
+		Description: "Replace a context. The URI cannot change and the version must match the stored one.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in UpdateContextInput) (*mcp.CallToolResult, *model.Context, error) {
+		c := in.context()
+		c.Version = in.Version
+
+		updated, err := svc.UpdateContext(c)
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, updated, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_context",
+		Description: "Delete a context and everything inside it. Non-empty contexts require confirm: true.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in DeleteContextInput) (*mcp.CallToolResult, *DeleteResult, error) {
+		contained, err := svc.DeleteContext(in.URI, in.Confirm)
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, &DeleteResult{URI: in.URI, Deleted: true, Contained: contained}, nil
+	})
+}
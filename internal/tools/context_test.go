@@ -0,0 +1,59 @@
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/tools"
+)
+
+func TestContextTools_Lifecycle(t *testing.T) {
+	// given
+	session := newSession(t)
+
+	// when — create
+	var created model.Context
+	callTool(t, session, "create_context", map[string]any{"uri": contextURI, "name": "E-commerce"}, &created)
+	// then
+	assert.Equal(t, 1, created.Version)
+
+	// when — update
+	var updated model.Context
+	callTool(t, session, "update_context", map[string]any{
+		"uri":     contextURI,
+		"name":    "Shop",
+		"version": 1,
+	}, &updated)
+	// then
+	assert.Equal(t, 2, updated.Version)
+
+	// when — get
+	var got model.Context
+	callTool(t, session, "get_context", map[string]any{"uri": contextURI}, &got)
+	// then
+	assert.Equal(t, "Shop", got.Name)
+
+	// when — delete
+	var deleted tools.DeleteResult
+	callTool(t, session, "delete_context", map[string]any{"uri": contextURI}, &deleted)
+	// then
+	assert.True(t, deleted.Deleted)
+}
+
+func TestDeleteContextTool_RequiresConfirmation(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	// when
+	appErr := callToolError(t, session, "delete_context", map[string]any{"uri": contextURI})
+	// then
+	assert.Equal(t, outputs.ErrConfirmationRequired, appErr.ErrorCode)
+
+	// when — confirmed
+	var deleted tools.DeleteResult
+	callTool(t, session, "delete_context", map[string]any{"uri": contextURI, "confirm": true}, &deleted)
+	// then
+	assert.Equal(t, []string{domainURI}, deleted.Contained)
+}
@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+type DomainURIInput struct {
+	URI string `json:"uri" jsonschema:"domain URI, e.g. scio://contexts/<context>/domains/<slug>"`
+}
+
+type CreateDomainInput struct {
+	URI       string              `json:"uri" jsonschema:"domain URI, e.g. scio://contexts/<context>/domains/<slug>"`
+	Name      string              `json:"name" jsonschema:"human readable name of the domain"`
+	Tags      []string            `json:"tags,omitempty" jsonschema:"tags classifying the domain, as URIs or short slugs resolved against the domain's context first and globally second"`
+	Relations []model.RelationRef `json:"relations,omitempty" jsonschema:"outgoing relations to other entities; relation types may be given as short slugs"`
+	Body      string              `json:"body,omitempty" jsonschema:"markdown description of the domain"`
+}
+
+type UpdateDomainInput struct {
+	CreateDomainInput
+	Version int `json:"version" jsonschema:"current version of the domain, as returned by get_domain"`
+}
+
+type DeleteDomainInput struct {
+	URI     string `json:"uri" jsonschema:"domain URI, e.g. scio://contexts/<context>/domains/<slug>"`
+	Confirm bool   `json:"confirm,omitempty" jsonschema:"must be true to delete a domain that still contains concepts"`
+}
+
+func (in *CreateDomainInput) domain() *model.Domain {
+	return &model.Domain{
+		URI:       in.URI,
+		Name:      in.Name,
+		Tags:      in.Tags,
+		Relations: in.Relations,
+		Body:      in.Body,
+	}
+}
+
+func registerDomainTools(server *mcp.Server, svc *service.Service) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_domain",
+		Description: "Read a domain, including its current version.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in DomainURIInput) (*mcp.CallToolResult, *model.Domain, error) {
+		c, err := svc.GetDomain(in.URI)
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, c, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_domain",
+		Description: "Create a new domain inside an existing context. Fails if the URI is already in use.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in CreateDomainInput) (*mcp.CallToolResult, *model.Domain, error) {
+		c, err := svc.CreateDomain(in.domain())
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, c, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_domain",
+		Description: "Replace a domain. The URI cannot change and the version must match the stored one.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in UpdateDomainInput) (*mcp.CallToolResult, *model.Domain, error) {
+		c := in.domain()
+		c.Version = in.Version
+
+		updated, err := svc.UpdateDomain(c)
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, updated, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_domain",
+		Description: "Delete a domain and its concepts. Domains that still contain concepts require confirm: true.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in DeleteDomainInput) (*mcp.CallToolResult, *DeleteResult, error) {
+		contained, err := svc.DeleteDomain(in.URI, in.Confirm)
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, &DeleteResult{URI: in.URI, Deleted: true, Contained: contained}, nil
+	})
+}
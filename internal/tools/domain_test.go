@@ -0,0 +1,49 @@
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/tools"
+)
+
+func TestCreateDomainTool_ParentNotFound(t *testing.T) {
+	// given
+	session := newSession(t)
+	// when
+	appErr := callToolError(t, session, "create_domain", map[string]any{"uri": domainURI, "name": "Pricing"})
+	// then
+	assert.Equal(t, outputs.ErrParentNotFound, appErr.ErrorCode)
+	assert.Equal(t, contextURI, appErr.Details["parent"])
+}
+
+func TestDomainTools_UpdateAndDelete(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	callTool(t, session, "create_concept", map[string]any{"uri": conceptURI, "name": "Discount"}, nil)
+
+	// when — update
+	var updated model.Domain
+	callTool(t, session, "update_domain", map[string]any{
+		"uri":     domainURI,
+		"name":    "Pricing Rules",
+		"version": 1,
+	}, &updated)
+	// then
+	assert.Equal(t, 2, updated.Version)
+	assert.Equal(t, "Pricing Rules", updated.Name)
+
+	// when — delete without confirmation
+	appErr := callToolError(t, session, "delete_domain", map[string]any{"uri": domainURI})
+	// then
+	assert.Equal(t, outputs.ErrConfirmationRequired, appErr.ErrorCode)
+
+	// when — delete confirmed
+	var deleted tools.DeleteResult
+	callTool(t, session, "delete_domain", map[string]any{"uri": domainURI, "confirm": true}, &deleted)
+	// then
+	assert.Equal(t, []string{conceptURI}, deleted.Contained)
+}
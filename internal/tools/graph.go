@@ -0,0 +1,40 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+type GraphInput struct {
+	URI           string   `json:"uri" jsonschema:"URI of the entity to start from"`
+	Depth         int      `json:"depth,omitempty" jsonschema:"number of relation hops to follow, from 1 to 5; 1 by default"`
+	Direction     string   `json:"direction,omitempty" jsonschema:"follow outgoing, incoming or both relations; both by default"`
+	RelationTypes []string `json:"relation_types,omitempty" jsonschema:"only follow relations of these relation type URIs"`
+	EntityTypes   []string `json:"entity_types,omitempty" jsonschema:"only reach entities of these types (context, domain, concept)"`
+	Infer         bool     `json:"infer,omitempty" jsonschema:"also return the edges implied by symmetric, inverse_of and transitive relation types, marked inferred with their justification"`
+}
+
+func registerGraphTools(server *mcp.Server, svc *service.Service) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "traverse_graph",
+		Description: "Return the entities around a URI and the relations between them, walking outgoing and incoming relations " +
+			"up to the given depth. Use it to see what surrounds a concept in one call.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in GraphInput) (*mcp.CallToolResult, *service.Graph, error) {
+		g, err := svc.Graph(service.GraphQuery{
+			URI:           in.URI,
+			Depth:         in.Depth,
+			Direction:     in.Direction,
+			RelationTypes: in.RelationTypes,
+			EntityTypes:   in.EntityTypes,
+			Infer:         in.Infer,
+		})
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, g, nil
+	})
+}
@@ -0,0 +1,77 @@
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+func TestTraverseGraphTool(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	callTool(t, session, "create_relation_type", map[string]any{"uri": "scio://relations/part-of"}, nil)
+	callTool(t, session, "create_concept", map[string]any{
+		"uri":       conceptURI,
+		"name":      "Discount",
+		"relations": []map[string]any{{"type": "part-of", "target": domainURI}},
+	}, nil)
+
+	// when
+	var g service.Graph
+	callTool(t, session, "traverse_graph", map[string]any{"uri": domainURI}, &g)
+
+	// then
+	assert.Equal(t, []service.GraphNode{
+		{URI: domainURI, Entity: "domain", Name: "Pricing"},
+		{URI: conceptURI, Entity: "concept", Name: "Discount", Depth: 1},
+	}, g.Nodes)
+	assert.Equal(t, []service.GraphEdge{{Source: conceptURI, Type: "scio://relations/part-of", Target: domainURI}}, g.Edges)
+}
+
+func TestTraverseGraphTool_Infer(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	callTool(t, session, "create_relation_type", map[string]any{"uri": "scio://relations/related-to", "symmetric": true}, nil)
+	callTool(t, session, "create_concept", map[string]any{
+		"uri":       conceptURI,
+		"name":      "Discount",
+		"relations": []map[string]any{{"type": "related-to", "target": domainURI}},
+	}, nil)
+
+	// when
+	var g service.Graph
+	callTool(t, session, "traverse_graph", map[string]any{"uri": domainURI, "direction": "outgoing", "infer": true}, &g)
+
+	// then
+	asserted := service.AssertedEdge{Source: conceptURI, Type: "scio://relations/related-to", Target: domainURI}
+	assert.Equal(t, []service.GraphEdge{{
+		Source:        domainURI,
+		Type:          "scio://relations/related-to",
+		Target:        conceptURI,
+		Inferred:      true,
+		Justification: []service.AssertedEdge{asserted},
+	}}, g.Edges)
+}
+
+func TestTraverseGraphTool_NoRelations(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	// when
+	var g service.Graph
+	callTool(t, session, "traverse_graph", map[string]any{"uri": contextURI, "depth": 3}, &g)
+	// then
+	assert.Len(t, g.Nodes, 1)
+	assert.Empty(t, g.Edges)
+}
+
+func TestTraverseGraphTool_NotFound(t *testing.T) {
+	// given
+	session := newSession(t)
+	// when
+	appErr := callToolError(t, session, "traverse_graph", map[string]any{"uri": contextURI})
+	// then
+	assert.Equal(t, outputs.ErrNotFound, appErr.ErrorCode)
+}
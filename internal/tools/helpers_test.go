@@ -0,0 +1,102 @@
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/index"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+	"github.com/jjmrocha/knowledge-mcp/internal/tools"
+)
+
+// newSession starts a server over an empty, indexed store and returns a
+// connected client session.
+func newSession(t *testing.T) *mcp.ClientSession {
+	t.Helper()
+
+	root := t.TempDir()
+	require.NoError(t, storage.InitRootDirs(root))
+
+	idx, err := index.Open(root)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = idx.Close() })
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	tools.Register(server, service.New(root, service.WithIndex(idx)))
+
+	ctx := context.Background()
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+
+	return session
+}
+
+// callTool invokes a tool that is expected to succeed and decodes its
+// structured output into out.
+func callTool(t *testing.T, session *mcp.ClientSession, name string, args map[string]any, out any) {
+	t.Helper()
+
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args})
+	require.NoError(t, err)
+	require.False(t, res.IsError, "tool %s failed: %s", name, textContent(res))
+
+	if out != nil {
+		encoded, err := json.Marshal(res.StructuredContent)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(encoded, out))
+	}
+}
+
+// callToolError invokes a tool that is expected to fail and decodes the
+// AppError it reports.
+func callToolError(t *testing.T, session *mcp.ClientSession, name string, args map[string]any) *outputs.AppError {
+	t.Helper()
+
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args})
+	require.NoError(t, err)
+	require.True(t, res.IsError, "tool %s unexpectedly succeeded", name)
+
+	var appErr outputs.AppError
+	require.NoError(t, json.Unmarshal([]byte(textContent(res)), &appErr))
+	return &appErr
+}
+
+func textContent(res *mcp.CallToolResult) string {
+	for _, c := range res.Content {
+		if text, ok := c.(*mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+
+	return ""
+}
+
+const (
+	contextURI = "scio://contexts/ecommerce"
+	domainURI  = "scio://contexts/ecommerce/domains/pricing"
+)
+
+// newSessionWithDomain is newSession over a store that already holds the
+// contextURI context and the domainURI domain.
+func newSessionWithDomain(t *testing.T) *mcp.ClientSession {
+	t.Helper()
+
+	session := newSession(t)
+	callTool(t, session, "create_context", map[string]any{"uri": contextURI, "name": "E-commerce"}, nil)
+	callTool(t, session, "create_domain", map[string]any{"uri": domainURI, "name": "Pricing"}, nil)
+	return session
+}
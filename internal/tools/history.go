@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+type EntityHistoryInput struct {
+	URI string `json:"uri" jsonschema:"URI of any entity, including one that was deleted"`
+}
+
+type EntityHistoryOutput struct {
+	URI      string                  `json:"uri"`
+	Versions []service.EntityVersion `json:"versions"`
+}
+
+type DiffVersionsInput struct {
+	URI  string `json:"uri" jsonschema:"URI of any entity, including one that was deleted"`
+	From int    `json:"from" jsonschema:"version to compare from, usually the older one"`
+	To   int    `json:"to" jsonschema:"version to compare to, usually the newer one"`
+}
+
+func registerHistoryTools(server *mcp.Server, svc *service.Service) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "entity_history",
+		Description: "List the versions kept of an entity, oldest first, with when each was written. " +
+			"Deleted entities keep their history. Use diff_versions to see what changed between two of them.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in EntityHistoryInput) (*mcp.CallToolResult, *EntityHistoryOutput, error) {
+		versions, err := svc.History(in.URI)
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, &EntityHistoryOutput{URI: in.URI, Versions: versions}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "diff_versions",
+		Description: "Compare two versions of an entity: tags, relations and sources added and removed, " +
+			"other fields whose value changed, and a unified diff of the body.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in DiffVersionsInput) (*mcp.CallToolResult, *service.EntityDiff, error) {
+		diff, err := svc.Diff(in.URI, in.From, in.To)
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, diff, nil
+	})
+}
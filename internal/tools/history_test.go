@@ -0,0 +1,38 @@
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+	"github.com/jjmrocha/knowledge-mcp/internal/tools"
+)
+
+func TestHistoryTools(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	callTool(t, session, "create_concept", map[string]any{"uri": conceptURI, "name": "Discount", "body": "Discounts.\n"}, nil)
+	callTool(t, session, "update_concept", map[string]any{"uri": conceptURI, "name": "Discount", "version": 1, "body": "Tiered discounts.\n"}, nil)
+
+	// when
+	var history tools.EntityHistoryOutput
+	callTool(t, session, "entity_history", map[string]any{"uri": conceptURI}, &history)
+
+	// then
+	require.Len(t, history.Versions, 2)
+	assert.True(t, history.Versions[1].Current)
+
+	// when
+	var diff service.EntityDiff
+	callTool(t, session, "diff_versions", map[string]any{"uri": conceptURI, "from": 1, "to": 2}, &diff)
+
+	// then
+	assert.Empty(t, diff.Tags.Added)
+	assert.Contains(t, diff.Body, "-Discounts.\n+Tiered discounts.\n")
+
+	appErr := callToolError(t, session, "diff_versions", map[string]any{"uri": conceptURI, "from": 1, "to": 5})
+	assert.Equal(t, outputs.ErrNotFound, appErr.ErrorCode)
+}
@@ -0,0 +1,39 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+type MergeConceptsInput struct {
+	Source        string `json:"source" jsonschema:"URI of the concept merged away and deleted"`
+	SourceVersion int    `json:"source_version" jsonschema:"current version of the source concept"`
+	Target        string `json:"target" jsonschema:"URI of the concept that survives the merge"`
+	TargetVersion int    `json:"target_version" jsonschema:"current version of the target concept"`
+	BodyStrategy  string `json:"body_strategy,omitempty" jsonschema:"concat appends the source body to the target body under a separator, keep_target keeps only the target body; concat by default"`
+}
+
+func registerMergeTools(server *mcp.Server, svc *service.Service) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "merge_concepts",
+		Description: "Merge a duplicate concept into another one: tags, relations and sources are united without duplicates, " +
+			"bodies are combined, every reference to the source is redirected to the target and the source is deleted. " +
+			"The merge is refused if the result would break a tag or relation type rule.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in MergeConceptsInput) (*mcp.CallToolResult, *service.MergeResult, error) {
+		result, err := svc.MergeConcepts(service.MergeRequest{
+			Source:        in.Source,
+			SourceVersion: in.SourceVersion,
+			Target:        in.Target,
+			TargetVersion: in.TargetVersion,
+			Body:          in.BodyStrategy,
+		})
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, result, nil
+	})
+}
@@ -0,0 +1,44 @@
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+func TestMergeConceptsTool(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	couponURI := domainURI + "/concepts/coupon"
+	callTool(t, session, "create_relation_type", map[string]any{"uri": "scio://relations/part-of"}, nil)
+	callTool(t, session, "create_concept", map[string]any{"uri": conceptURI, "name": "Discount", "body": "Discounts.\n"}, nil)
+	callTool(t, session, "create_concept", map[string]any{
+		"uri":       couponURI,
+		"name":      "Coupon",
+		"relations": []map[string]any{{"type": "part-of", "target": domainURI}},
+		"body":      "Coupons.\n",
+	}, nil)
+
+	// when
+	var result service.MergeResult
+	callTool(t, session, "merge_concepts", map[string]any{
+		"source":         couponURI,
+		"source_version": 1,
+		"target":         conceptURI,
+		"target_version": 1,
+		"body_strategy":  "keep_target",
+	}, &result)
+
+	// then
+	assert.Equal(t, 2, result.Concept.Version)
+	assert.Equal(t, "Discounts.\n", result.Concept.Body)
+	assert.Len(t, result.Concept.Relations, 1)
+	assert.Empty(t, result.Updated)
+
+	var followed model.Concept
+	callTool(t, session, "get_concept", map[string]any{"uri": couponURI}, &followed)
+	assert.Equal(t, conceptURI, followed.URI)
+}
@@ -0,0 +1,31 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+type MoveInput struct {
+	From    string `json:"from" jsonschema:"current URI of the entity"`
+	To      string `json:"to" jsonschema:"new URI of the entity, of the same entity type"`
+	Version int    `json:"version" jsonschema:"current version of the entity, for optimistic concurrency control"`
+}
+
+func registerMoveTools(server *mcp.Server, svc *service.Service) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "move_entity",
+		Description: "Rename an entity or move it elsewhere, e.g. a concept to another domain or a domain to another context. " +
+			"Contexts and domains move with everything inside them. Every tag, relation, broader/narrower and inverse_of reference " +
+			"to a moved entity is rewritten, and every file touched is reported.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in MoveInput) (*mcp.CallToolResult, *service.MoveResult, error) {
+		result, err := svc.Move(in.From, in.To, in.Version)
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, result, nil
+	})
+}
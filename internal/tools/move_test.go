@@ -0,0 +1,40 @@
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+func TestMoveEntityTool(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	callTool(t, session, "create_tag", map[string]any{"uri": "scio://tags/pricing"}, nil)
+	callTool(t, session, "create_concept", map[string]any{"uri": conceptURI, "name": "Discount", "tags": []string{"pricing"}}, nil)
+
+	// when
+	var result service.MoveResult
+	callTool(t, session, "move_entity", map[string]any{"from": "scio://tags/pricing", "to": "scio://tags/price", "version": 1}, &result)
+
+	// then
+	assert.Equal(t, []service.MovedEntity{{From: "scio://tags/pricing", To: "scio://tags/price", Version: 2}}, result.Moved)
+	assert.Equal(t, []service.UpdatedEntity{{URI: conceptURI, Version: 2}}, result.Updated)
+	assert.Equal(t, []string{"tags/pricing.md", "tags/price.md", "contexts/ecommerce/domains/pricing/discount.md"}, result.Files)
+
+	var concept model.Concept
+	callTool(t, session, "get_concept", map[string]any{"uri": conceptURI}, &concept)
+	assert.Equal(t, []string{"scio://tags/price"}, concept.Tags)
+}
+
+func TestMoveEntityTool_VersionConflict(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	// when
+	appErr := callToolError(t, session, "move_entity", map[string]any{"from": domainURI, "to": contextURI + "/domains/prices", "version": 7})
+	// then
+	assert.Equal(t, outputs.ErrVersionConflict, appErr.ErrorCode)
+}
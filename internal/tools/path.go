@@ -0,0 +1,40 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+type PathInput struct {
+	From             string `json:"from" jsonschema:"URI of the entity the paths start from"`
+	To               string `json:"to" jsonschema:"URI of the entity the paths lead to"`
+	IncludeTags      bool   `json:"include_tags,omitempty" jsonschema:"also connect entities sharing a tag"`
+	IncludeHierarchy bool   `json:"include_hierarchy,omitempty" jsonschema:"also connect contexts, domains and concepts with their parents and children"`
+	MaxDepth         int    `json:"max_depth,omitempty" jsonschema:"longest path to look for, from 1 to 10; 6 by default"`
+	Limit            int    `json:"limit,omitempty" jsonschema:"maximum number of shortest paths to return; 5 by default"`
+}
+
+func registerPathTools(server *mcp.Server, svc *service.Service) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "find_paths",
+		Description: "Find the shortest paths between two URIs, following relations in both directions. " +
+			"Every hop names the relation type and whether it was followed forwards or backwards. Use it to explain how two entities are connected.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in PathInput) (*mcp.CallToolResult, *service.PathResult, error) {
+		result, err := svc.ShortestPaths(service.PathQuery{
+			From:             in.From,
+			To:               in.To,
+			IncludeTags:      in.IncludeTags,
+			IncludeHierarchy: in.IncludeHierarchy,
+			MaxDepth:         in.MaxDepth,
+			Limit:            in.Limit,
+		})
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, result, nil
+	})
+}
@@ -0,0 +1,46 @@
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+func TestFindPathsTool(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	callTool(t, session, "create_relation_type", map[string]any{"uri": "scio://relations/part-of"}, nil)
+	callTool(t, session, "create_concept", map[string]any{
+		"uri":       conceptURI,
+		"name":      "Discount",
+		"relations": []map[string]any{{"type": "part-of", "target": domainURI}},
+	}, nil)
+
+	// when
+	var result service.PathResult
+	callTool(t, session, "find_paths", map[string]any{"from": domainURI, "to": conceptURI}, &result)
+
+	// then
+	assert.Equal(t, service.PathResult{
+		From:   domainURI,
+		To:     conceptURI,
+		Found:  true,
+		Length: 1,
+		Paths: []service.Path{{Hops: []service.PathHop{
+			{From: domainURI, To: conceptURI, Kind: "relation", Type: "scio://relations/part-of", Direction: "incoming"},
+		}}},
+	}, result)
+}
+
+func TestFindPathsTool_NotConnected(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	// when
+	var result service.PathResult
+	callTool(t, session, "find_paths", map[string]any{"from": domainURI, "to": contextURI}, &result)
+	// then
+	assert.False(t, result.Found)
+	assert.Empty(t, result.Paths)
+}
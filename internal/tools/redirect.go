@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+type ResolveInput struct {
+	URI string `json:"uri" jsonschema:"URI to look up, possibly retired by a move or merge"`
+}
+
+type RedirectURIInput struct {
+	URI string `json:"uri" jsonschema:"retired URI whose redirect is removed"`
+}
+
+type ListRedirectsOutput struct {
+	Redirects []service.RedirectInfo `json:"redirects"`
+}
+
+func registerRedirectTools(server *mcp.Server, svc *service.Service) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "resolve_uri",
+		Description: "Look a URI up and tell where the entity is stored now. URIs retired by move_entity or merge_concepts " +
+			"resolve to their replacement with moved set and a notice; the get tools follow these redirects on their own.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in ResolveInput) (*mcp.CallToolResult, *service.Resolution, error) {
+		resolution, err := svc.Resolve(in.URI)
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, resolution, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_redirects",
+		Description: "List every retired URI with the URI that replaced it and whether that entity still exists, to review and prune redirects.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, *ListRedirectsOutput, error) {
+		redirects, err := svc.Redirects()
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, &ListRedirectsOutput{Redirects: redirects}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_redirect",
+		Description: "Remove the redirect from a retired URI. The URI will no longer resolve.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in RedirectURIInput) (*mcp.CallToolResult, *DeleteResult, error) {
+		if err := svc.DeleteRedirect(in.URI); err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, &DeleteResult{URI: in.URI, Deleted: true}, nil
+	})
+}
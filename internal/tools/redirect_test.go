@@ -0,0 +1,46 @@
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+	"github.com/jjmrocha/knowledge-mcp/internal/tools"
+)
+
+func TestRedirectTools(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	pricesURI := contextURI + "/domains/prices"
+	callTool(t, session, "move_entity", map[string]any{"from": domainURI, "to": pricesURI, "version": 1}, nil)
+
+	// when
+	var resolution service.Resolution
+	callTool(t, session, "resolve_uri", map[string]any{"uri": domainURI}, &resolution)
+
+	// then
+	assert.True(t, resolution.Moved)
+	assert.Equal(t, pricesURI, resolution.Target)
+	assert.NotEmpty(t, resolution.Notice)
+
+	var domain model.Domain
+	callTool(t, session, "get_domain", map[string]any{"uri": domainURI}, &domain)
+	assert.Equal(t, pricesURI, domain.URI)
+
+	var listed tools.ListRedirectsOutput
+	callTool(t, session, "list_redirects", map[string]any{}, &listed)
+	require.Len(t, listed.Redirects, 1)
+	assert.Equal(t, domainURI, listed.Redirects[0].From)
+	assert.True(t, listed.Redirects[0].TargetExists)
+
+	// when
+	callTool(t, session, "delete_redirect", map[string]any{"uri": domainURI}, nil)
+
+	// then
+	appErr := callToolError(t, session, "resolve_uri", map[string]any{"uri": domainURI})
+	assert.Equal(t, outputs.ErrNotFound, appErr.ErrorCode)
+}
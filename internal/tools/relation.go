@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+type RelationTypeURIInput struct {
+	URI string `json:"uri" jsonschema:"relation type URI, e.g. scio://relations/<slug> or scio://contexts/<context>/relations/<slug>"`
+}
+
+type CreateRelationTypeInput struct {
+	URI                   string   `json:"uri" jsonschema:"relation type URI, e.g. scio://relations/<slug> or scio://contexts/<context>/relations/<slug>"`
+	InverseOf             string   `json:"inverse_of,omitempty" jsonschema:"URI of the inverse relation type; it is updated to point back automatically"`
+	AllowedSourceEntities []string `json:"allowed_source_entities,omitempty" jsonschema:"entity types allowed as source (context, domain, concept); defaults to all"`
+	AllowedTargetEntities []string `json:"allowed_target_entities,omitempty" jsonschema:"entity types allowed as target (context, domain, concept); defaults to all"`
+	Transitive            bool     `json:"transitive,omitempty" jsonschema:"whether A→B and B→C imply A→C"`
+	Symmetric             bool     `json:"symmetric,omitempty" jsonschema:"whether A→B implies B→A; symmetric relations cannot declare inverse_of"`
+	Body                  string   `json:"body,omitempty" jsonschema:"markdown description of the relation type"`
+}
+
+type UpdateRelationTypeInput struct {
+	CreateRelationTypeInput
+	Version int `json:"version" jsonschema:"current version of the relation type, as returned by get_relation_type"`
+}
+
+func (in *CreateRelationTypeInput) relationType() *model.RelationType {
+	return &model.RelationType{
+		URI:                   in.URI,
+		InverseOf:             in.InverseOf,
+		AllowedSourceEntities: in.AllowedSourceEntities,
+		AllowedTargetEntities: in.AllowedTargetEntities,
+		Transitive:            in.Transitive,
+		Symmetric:             in.Symmetric,
+		Body:                  in.Body,
+	}
+}
+
+func registerRelationTypeTools(server *mcp.Server, svc *service.Service) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_relation_type",
+		Description: "Read a relation type, including its current version.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in RelationTypeURIInput) (*mcp.CallToolResult, *model.RelationType, error) {
+		r, err := svc.GetRelationType(in.URI)
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, r, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_relation_type",
+		Description: "Create a new relation type. Conflicting symmetric/inverse_of settings are rejected with RELATION_PROPERTIES_CONFLICT.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in CreateRelationTypeInput) (*mcp.CallToolResult, *model.RelationType, error) {
+		r, err := svc.CreateRelationType(in.relationType())
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, r, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_relation_type",
+		Description: "Replace a relation type. The version must match the stored one. The inverse relation type is kept pointing back.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in UpdateRelationTypeInput) (*mcp.CallToolResult, *model.RelationType, error) {
+		r := in.relationType()
+		r.Version = in.Version
+
+		updated, err := svc.UpdateRelationType(r)
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, updated, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_relation_type",
+		Description: "Delete a relation type. Fails with RELATION_IN_USE while any entity still has a relation of this type.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in RelationTypeURIInput) (*mcp.CallToolResult, *DeleteResult, error) {
+		if err := svc.DeleteRelationType(in.URI); err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, &DeleteResult{URI: in.URI, Deleted: true}, nil
+	})
+}
@@ -0,0 +1,56 @@
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+)
+
+func TestRelationTypeTools_Inverse(t *testing.T) {
+	// given
+	session := newSession(t)
+	callTool(t, session, "create_relation_type", map[string]any{"uri": "scio://relations/part-of"}, nil)
+
+	// when
+	callTool(t, session, "create_relation_type", map[string]any{
+		"uri":        "scio://relations/has-part",
+		"inverse_of": "scio://relations/part-of",
+	}, nil)
+
+	// then
+	var partOf model.RelationType
+	callTool(t, session, "get_relation_type", map[string]any{"uri": "scio://relations/part-of"}, &partOf)
+	assert.Equal(t, "scio://relations/has-part", partOf.InverseOf)
+}
+
+func TestCreateRelationTypeTool_SymmetricWithInverse(t *testing.T) {
+	// given
+	session := newSession(t)
+	callTool(t, session, "create_relation_type", map[string]any{"uri": "scio://relations/part-of"}, nil)
+	// when
+	appErr := callToolError(t, session, "create_relation_type", map[string]any{
+		"uri":        "scio://relations/related-to",
+		"symmetric":  true,
+		"inverse_of": "scio://relations/part-of",
+	})
+	// then
+	assert.Equal(t, outputs.ErrRelationPropertiesConflict, appErr.ErrorCode)
+}
+
+func TestDeleteRelationTypeTool_InUse(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	callTool(t, session, "create_relation_type", map[string]any{"uri": "scio://relations/part-of"}, nil)
+	callTool(t, session, "create_concept", map[string]any{
+		"uri":       conceptURI,
+		"name":      "Discount",
+		"relations": []map[string]any{{"type": "scio://relations/part-of", "target": domainURI}},
+	}, nil)
+	// when
+	appErr := callToolError(t, session, "delete_relation_type", map[string]any{"uri": "scio://relations/part-of"})
+	// then
+	assert.Equal(t, outputs.ErrRelationInUse, appErr.ErrorCode)
+}
@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+const markdownMIMEType = "text/markdown"
+
+// ServerOptions returns the options of a server the knowledge store is
+// registered with, letting clients subscribe to entity resources.
+func ServerOptions() *mcp.ServerOptions {
+	return &mcp.ServerOptions{
+		SubscribeHandler: func(_ context.Context, req *mcp.SubscribeRequest) error {
+			_, err := uri.Parse(req.Params.URI)
+			return err
+		},
+		UnsubscribeHandler: func(context.Context, *mcp.UnsubscribeRequest) error {
+			return nil
+		},
+	}
+}
+
+func registerResources(server *mcp.Server, svc *service.Service) {
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "entity",
+		URITemplate: "scio://{+path}",
+		MIMEType:    markdownMIMEType,
+		Description: "The markdown file of an entity. Subscribe to be notified when the file changes, including edits made outside the server.",
+	}, func(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		content, err := svc.Markdown(req.Params.URI)
+
+		var appErr *outputs.AppError
+		if errors.As(err, &appErr) && appErr.ErrorCode == outputs.ErrNotFound {
+			return nil, mcp.ResourceNotFoundError(req.Params.URI)
+		}
+
+		if err != nil {
+			return nil, failure(err)
+		}
+
+		return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{
+			{URI: req.Params.URI, MIMEType: markdownMIMEType, Text: content},
+		}}, nil
+	})
+}
+
+// Watch polls the store every interval until ctx is done. Entity files
+// changed outside the server, by an editor or a git pull, are indexed again.
+// The clients subscribed to a file are notified whoever changed it: this
+// server, another server sharing the root directory or an outside edit.
+func Watch(ctx context.Context, server *mcp.Server, svc *service.Service, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		changed, err := svc.SyncIndex()
+		if err != nil {
+			log.Printf("failed to sync the index with the files on disk: %v", err)
+			continue
+		}
+
+		for _, raw := range changed {
+			if err := server.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: raw}); err != nil {
+				log.Printf("failed to notify the update of %s: %v", raw, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package tools_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/index"
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+	"github.com/jjmrocha/knowledge-mcp/internal/storage"
+	"github.com/jjmrocha/knowledge-mcp/internal/tools"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// newWatchedSession starts a watched server over a store holding the
+// conceptURI concept and returns the store root, a connected client session
+// and the channel receiving the resource-updated notifications.
+func newWatchedSession(t *testing.T) (string, *mcp.ClientSession, <-chan string) {
+	t.Helper()
+
+	root := t.TempDir()
+	require.NoError(t, storage.InitRootDirs(root))
+
+	idx, err := index.Open(root)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = idx.Close() })
+
+	svc := service.New(root, service.WithIndex(idx))
+	_, err = svc.CreateContext(&model.Context{URI: contextURI})
+	require.NoError(t, err)
+	_, err = svc.CreateDomain(&model.Domain{URI: domainURI})
+	require.NoError(t, err)
+	_, err = svc.CreateConcept(&model.Concept{URI: conceptURI, Name: "Discount"})
+	require.NoError(t, err)
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, tools.ServerOptions())
+	tools.Register(server, svc)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go tools.Watch(ctx, server, svc, 10*time.Millisecond)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	updated := make(chan string, 10)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			updated <- req.Params.URI
+		},
+	})
+
+	session, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+
+	return root, session, updated
+}
+
+func TestEntityResource(t *testing.T) {
+	// given
+	_, session, _ := newWatchedSession(t)
+	// when
+	res, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: conceptURI})
+	// then
+	require.NoError(t, err)
+	require.Len(t, res.Contents, 1)
+	assert.Equal(t, "text/markdown", res.Contents[0].MIMEType)
+	assert.Contains(t, res.Contents[0].Text, "name: Discount")
+
+	_, err = session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: domainURI + "/concepts/missing"})
+	assert.Error(t, err)
+}
+
+// editExternally rewrites the file of conceptURI with its name set to name,
+// as an editor would.
+func editExternally(t *testing.T, root, name string) {
+	t.Helper()
+
+	u, err := uri.Parse(conceptURI)
+	require.NoError(t, err)
+
+	fileName := storage.FileName(root, u)
+	content, err := os.ReadFile(fileName) //nolint:gosec
+	require.NoError(t, err)
+
+	edited := strings.Replace(string(content), "name: Discount", "name: "+name, 1)
+	require.NoError(t, os.WriteFile(fileName, []byte(edited), 0o600))
+}
+
+func requireUpdated(t *testing.T, updated <-chan string, raw string) {
+	t.Helper()
+
+	select {
+	case got := <-updated:
+		assert.Equal(t, raw, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no resource-updated notification received")
+	}
+}
+
+func TestWatch_NotifiesSubscribers(t *testing.T) {
+	// given
+	root, session, updated := newWatchedSession(t)
+	require.NoError(t, session.Subscribe(context.Background(), &mcp.SubscribeParams{URI: conceptURI}))
+	// when
+	editExternally(t, root, "Discounts")
+	// then
+	requireUpdated(t, updated, conceptURI)
+
+	var c model.Concept
+	callTool(t, session, "get_concept", map[string]any{"uri": conceptURI}, &c)
+	assert.Equal(t, "Discounts", c.Name)
+}
+
+func TestWatch_NotifiesServerWrites(t *testing.T) {
+	// given
+	root, session, updated := newWatchedSession(t)
+	require.NoError(t, session.Subscribe(context.Background(), &mcp.SubscribeParams{URI: conceptURI}))
+	editExternally(t, root, "Discounts")
+	requireUpdated(t, updated, conceptURI)
+
+	idx, err := index.Open(root)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = idx.Close() })
+	other := service.New(root, service.WithIndex(idx))
+	// when — another server sharing the root directory writes
+	c, err := other.GetConcept(conceptURI)
+	require.NoError(t, err)
+	c.Name = "Rebate"
+	_, err = other.UpdateConcept(c)
+	require.NoError(t, err)
+	// then
+	requireUpdated(t, updated, conceptURI)
+
+	// when — this server writes
+	callTool(t, session, "update_concept", map[string]any{"uri": conceptURI, "name": "Voucher", "version": 2}, nil)
+	// then
+	requireUpdated(t, updated, conceptURI)
+}
@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+type FuzzySearchInput struct {
+	Query       string   `json:"query" jsonschema:"text to fuzzy-match against entity names and URI slugs"`
+	EntityTypes []string `json:"entity_types,omitempty" jsonschema:"restrict hits to these entity types (context, domain, concept)"`
+	Context     string   `json:"context,omitempty" jsonschema:"restrict hits to the entities of this context URI"`
+	Tag         string   `json:"tag,omitempty" jsonschema:"restrict hits to entities tagged with this tag, as a URI or a short slug"`
+	Limit       int      `json:"limit,omitempty" jsonschema:"maximum number of hits, 20 by default"`
+}
+
+type FuzzySearchOutput struct {
+	Hits []service.SearchHit `json:"hits"`
+}
+
+type FullTextSearchInput struct {
+	Query       string   `json:"query" jsonschema:"words that must all appear in the name, body or tags of a hit"`
+	EntityTypes []string `json:"entity_types,omitempty" jsonschema:"restrict hits to these entity types (context, domain, concept)"`
+	Context     string   `json:"context,omitempty" jsonschema:"restrict hits to the entities of this context URI"`
+	Domain      string   `json:"domain,omitempty" jsonschema:"restrict hits to the entities of this domain URI"`
+	Tag         string   `json:"tag,omitempty" jsonschema:"restrict hits to entities tagged with this tag, as a URI or a short slug"`
+	Limit       int      `json:"limit,omitempty" jsonschema:"maximum number of hits, 20 by default"`
+}
+
+type FullTextSearchOutput struct {
+	Hits []service.TextSearchHit `json:"hits"`
+}
+
+func registerSearchTools(server *mcp.Server, svc *service.Service) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "fuzzy_search",
+		Description: "Find contexts, domains and concepts whose name or URI slug approximately matches a query, best match first. " +
+			"Use it to recover the right URI from a slightly wrong guess.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in FuzzySearchInput) (*mcp.CallToolResult, *FuzzySearchOutput, error) {
+		hits, err := svc.Search(service.SearchQuery{
+			Query:       in.Query,
+			EntityTypes: in.EntityTypes,
+			Context:     in.Context,
+			Tag:         in.Tag,
+			Limit:       in.Limit,
+		})
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, &FuzzySearchOutput{Hits: hits}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "full_text_search",
+		Description: "Search the names, markdown bodies and tags of contexts, domains and concepts. " +
+			"Hits are ranked by relevance and carry a snippet with the matched words wrapped in **.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in FullTextSearchInput) (*mcp.CallToolResult, *FullTextSearchOutput, error) {
+		hits, err := svc.SearchText(service.TextSearchQuery{
+			Query:       in.Query,
+			EntityTypes: in.EntityTypes,
+			Context:     in.Context,
+			Domain:      in.Domain,
+			Tag:         in.Tag,
+			Limit:       in.Limit,
+		})
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, &FullTextSearchOutput{Hits: hits}, nil
+	})
+}
@@ -0,0 +1,56 @@
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/tools"
+)
+
+func TestFuzzySearchTool(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	callTool(t, session, "create_concept", map[string]any{"uri": conceptURI, "name": "Discount"}, nil)
+
+	// when
+	var out tools.FuzzySearchOutput
+	callTool(t, session, "fuzzy_search", map[string]any{"query": "dscount", "entity_types": []string{"concept"}}, &out)
+
+	// then
+	require.Len(t, out.Hits, 1)
+	assert.Equal(t, conceptURI, out.Hits[0].URI)
+	assert.Equal(t, "Discount", out.Hits[0].Name)
+	assert.Equal(t, "concept", out.Hits[0].Entity)
+	assert.NotEmpty(t, out.Hits[0].Positions)
+}
+
+func TestFuzzySearchTool_EmptyQuery(t *testing.T) {
+	// given
+	session := newSession(t)
+	// when
+	appErr := callToolError(t, session, "fuzzy_search", map[string]any{"query": ""})
+	// then
+	assert.Equal(t, outputs.ErrValidationFailed, appErr.ErrorCode)
+}
+
+func TestFullTextSearchTool(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	callTool(t, session, "create_concept", map[string]any{
+		"uri":  conceptURI,
+		"name": "Discount",
+		"body": "Tiered discounts are applied at checkout.\n",
+	}, nil)
+
+	// when
+	var out tools.FullTextSearchOutput
+	callTool(t, session, "full_text_search", map[string]any{"query": "checkout", "domain": domainURI}, &out)
+
+	// then
+	require.Len(t, out.Hits, 1)
+	assert.Equal(t, conceptURI, out.Hits[0].URI)
+	assert.Equal(t, "Tiered discounts are applied at **checkout**.\n", out.Hits[0].Snippet)
+}
@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+type TagURIInput struct {
+	URI string `json:"uri" jsonschema:"tag URI, e.g. scio://tags/<slug> or scio://contexts/<context>/tags/<slug>"`
+}
+
+type CreateTagInput struct {
+	URI             string   `json:"uri" jsonschema:"tag URI, e.g. scio://tags/<slug> or scio://contexts/<context>/tags/<slug>"`
+	AllowedEntities []string `json:"allowed_entities,omitempty" jsonschema:"entity types that may use the tag (context, domain, concept); defaults to all"`
+	Broader         []string `json:"broader,omitempty" jsonschema:"URIs of more general tags; their narrower lists are updated automatically"`
+	Narrower        []string `json:"narrower,omitempty" jsonschema:"URIs of more specific tags; their broader lists are updated automatically"`
+	Body            string   `json:"body,omitempty" jsonschema:"markdown description of the tag"`
+}
+
+type UpdateTagInput struct {
+	CreateTagInput
+	Version int `json:"version" jsonschema:"current version of the tag, as returned by get_tag"`
+}
+
+func (in *CreateTagInput) tag() *model.Tag {
+	return &model.Tag{
+		URI:             in.URI,
+		AllowedEntities: in.AllowedEntities,
+		Broader:         in.Broader,
+		Narrower:        in.Narrower,
+		Body:            in.Body,
+	}
+}
+
+func registerTagTools(server *mcp.Server, svc *service.Service) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_tag",
+		Description: "Read a tag, including its current version and hierarchy links.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in TagURIInput) (*mcp.CallToolResult, *model.Tag, error) {
+		t, err := svc.GetTag(in.URI)
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, t, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_tag",
+		Description: "Create a new tag. Linked broader and narrower tags are updated to point back; links that would form a loop are rejected with TAG_CYCLE.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in CreateTagInput) (*mcp.CallToolResult, *model.Tag, error) {
+		t, err := svc.CreateTag(in.tag())
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, t, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_tag",
+		Description: "Replace a tag. The version must match the stored one. Back-links on linked tags are added or removed to match.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in UpdateTagInput) (*mcp.CallToolResult, *model.Tag, error) {
+		t := in.tag()
+		t.Version = in.Version
+
+		updated, err := svc.UpdateTag(t)
+		if err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, updated, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_tag",
+		Description: "Delete a tag. Fails with TAG_IN_USE while any entity still lists it in its tags.",
+	}, func(_ context.Context, _ *mcp.CallToolRequest, in TagURIInput) (*mcp.CallToolResult, *DeleteResult, error) {
+		if err := svc.DeleteTag(in.URI); err != nil {
+			return nil, nil, failure(err)
+		}
+
+		return nil, &DeleteResult{URI: in.URI, Deleted: true}, nil
+	})
+}
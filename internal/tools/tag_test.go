@@ -0,0 +1,63 @@
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/tools"
+)
+
+func TestTagTools_Hierarchy(t *testing.T) {
+	// given
+	session := newSession(t)
+	callTool(t, session, "create_tag", map[string]any{"uri": "scio://tags/pricing"}, nil)
+
+	// when
+	var discount model.Tag
+	callTool(t, session, "create_tag", map[string]any{
+		"uri":     "scio://tags/discount",
+		"broader": []string{"scio://tags/pricing"},
+	}, &discount)
+
+	// then
+	var pricing model.Tag
+	callTool(t, session, "get_tag", map[string]any{"uri": "scio://tags/pricing"}, &pricing)
+	assert.Equal(t, []string{"scio://tags/discount"}, pricing.Narrower)
+
+	// when — closing the loop
+	appErr := callToolError(t, session, "update_tag", map[string]any{
+		"uri":      "scio://tags/pricing",
+		"broader":  []string{"scio://tags/discount"},
+		"narrower": pricing.Narrower,
+		"version":  pricing.Version,
+	})
+	// then
+	assert.Equal(t, outputs.ErrTagCycle, appErr.ErrorCode)
+}
+
+func TestDeleteTagTool_InUse(t *testing.T) {
+	// given
+	session := newSessionWithDomain(t)
+	callTool(t, session, "create_tag", map[string]any{"uri": "scio://tags/pricing"}, nil)
+	callTool(t, session, "create_concept", map[string]any{
+		"uri":  conceptURI,
+		"name": "Discount",
+		"tags": []string{"scio://tags/pricing"},
+	}, nil)
+
+	// when
+	appErr := callToolError(t, session, "delete_tag", map[string]any{"uri": "scio://tags/pricing"})
+	// then
+	assert.Equal(t, outputs.ErrTagInUse, appErr.ErrorCode)
+	assert.Equal(t, []any{conceptURI}, appErr.Details["referenced_by"])
+
+	// when — untagged
+	callTool(t, session, "delete_concept", map[string]any{"uri": conceptURI}, nil)
+	var deleted tools.DeleteResult
+	callTool(t, session, "delete_tag", map[string]any{"uri": "scio://tags/pricing"}, &deleted)
+	// then
+	assert.True(t, deleted.Deleted)
+}
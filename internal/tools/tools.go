@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/service"
+)
+
+// Register adds every knowledge store tool and resource to the MCP server.
+func Register(server *mcp.Server, svc *service.Service) {
+	registerContextTools(server, svc)
+	registerDomainTools(server, svc)
+	registerConceptTools(server, svc)
+	registerTagTools(server, svc)
+	registerRelationTypeTools(server, svc)
+	registerBatchTools(server, svc)
+	registerSearchTools(server, svc)
+	registerGraphTools(server, svc)
+	registerBacklinkTools(server, svc)
+	registerPathTools(server, svc)
+	registerMoveTools(server, svc)
+	registerMergeTools(server, svc)
+	registerRedirectTools(server, svc)
+	registerHistoryTools(server, svc)
+	registerResources(server, svc)
+}
+
+// toolError carries an AppError to the client. The SDK reports tool errors
+// using Error(), so the message is the JSON encoding of the AppError.
+type toolError struct {
+	appErr *outputs.AppError
+}
+
+func (e *toolError) Error() string {
+	encoded, err := json.Marshal(e.appErr)
+	if err != nil {
+		return e.appErr.Message
+	}
+
+	return string(encoded)
+}
+
+func (e *toolError) Unwrap() error {
+	return e.appErr
+}
+
+func failure(err error) error {
+	var appErr *outputs.AppError
+	if errors.As(err, &appErr) {
+		return &toolError{appErr: appErr}
+	}
+
+	return err
+}
+
+// DeleteResult is returned by every delete tool. Contained lists the
+// entities removed together with a context or domain.
+type DeleteResult struct {
+	URI       string   `json:"uri"`
+	Deleted   bool     `json:"deleted"`
+	Contained []string `json:"contained,omitempty"`
+}
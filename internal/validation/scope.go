@@ -0,0 +1,117 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z]+[a-z0-9-]*$`)
+
+// Scope returns the context an entity belongs to, or "" for global entities.
+// A context is its own scope.
+func Scope(u *uri.URI) string {
+	switch {
+	case u.Entity == model.EntityTypeContext:
+		return u.Slug
+	case u.Context != nil:
+		return *u.Context
+	default:
+		return ""
+	}
+}
+
+// scopeURI describes a scope for error messages.
+func scopeURI(scope string) string {
+	if scope == "" {
+		return "global"
+	}
+
+	return "scio://contexts/" + scope
+}
+
+// Resolve replaces short slugs in the tag and relation type references of
+// entity with full URIs. A slug resolves to the definition local to the
+// entity's context when one exists and to the global one otherwise.
+// References that are already URIs are left untouched.
+func (v *Validator) Resolve(entity any) {
+	u, err := uri.Parse(entityURI(entity))
+	if err != nil {
+		return
+	}
+
+	scope := Scope(u)
+
+	switch e := entity.(type) {
+	case *model.Context:
+		e.Tags = v.resolveAll(scope, "tags", e.Tags)
+		e.Relations = v.resolveRelationTypes(scope, e.Relations)
+	case *model.Domain:
+		e.Tags = v.resolveAll(scope, "tags", e.Tags)
+		e.Relations = v.resolveRelationTypes(scope, e.Relations)
+	case *model.Concept:
+		e.Tags = v.resolveAll(scope, "tags", e.Tags)
+		e.Relations = v.resolveRelationTypes(scope, e.Relations)
+	case *model.Tag:
+		e.Broader = v.resolveAll(scope, "tags", e.Broader)
+		e.Narrower = v.resolveAll(scope, "tags", e.Narrower)
+	case *model.RelationType:
+		if e.InverseOf != "" {
+			e.InverseOf = v.resolve(scope, "relations", e.InverseOf)
+		}
+	}
+}
+
+// ResolveTag expands a short tag slug to the URI of the tag it names in the
+// given scope, as Resolve does for the tags of an entity.
+func (v *Validator) ResolveTag(scope, ref string) string {
+	return v.resolve(scope, "tags", ref)
+}
+
+func (v *Validator) resolveAll(scope, kind string, refs []string) []string {
+	if refs == nil {
+		return nil
+	}
+
+	resolved := make([]string, len(refs))
+
+	for i, ref := range refs {
+		resolved[i] = v.resolve(scope, kind, ref)
+	}
+
+	return resolved
+}
+
+func (v *Validator) resolveRelationTypes(scope string, refs []model.RelationRef) []model.RelationRef {
+	if refs == nil {
+		return nil
+	}
+
+	resolved := make([]model.RelationRef, len(refs))
+
+	for i, ref := range refs {
+		resolved[i] = model.RelationRef{Type: v.resolve(scope, "relations", ref.Type), Target: ref.Target}
+	}
+
+	return resolved
+}
+
+// resolve expands a short slug of the given kind ("tags" or "relations").
+func (v *Validator) resolve(scope, kind, ref string) string {
+	if strings.HasPrefix(ref, "scio://") || !slugPattern.MatchString(ref) {
+		return ref
+	}
+
+	if scope != "" {
+		local := fmt.Sprintf("scio://contexts/%s/%s/%s", scope, kind, ref)
+
+		if u, err := uri.Parse(local); err == nil && v.store.Exists(u) {
+			return local
+		}
+	}
+
+	return fmt.Sprintf("scio://%s/%s", kind, ref)
+}
@@ -0,0 +1,159 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+	"github.com/jjmrocha/knowledge-mcp/internal/validation"
+)
+
+func newScopedValidator() *validation.Validator {
+	allEntities := []string{"context", "domain", "concept"}
+
+	return validation.New(&fakeStore{
+		tags: map[string]*model.Tag{
+			"scio://tags/pricing":                {URI: "scio://tags/pricing", AllowedEntities: allEntities},
+			"scio://tags/audit":                  {URI: "scio://tags/audit", AllowedEntities: allEntities},
+			"scio://contexts/shop/tags/pricing":  {URI: "scio://contexts/shop/tags/pricing", AllowedEntities: allEntities},
+			"scio://contexts/other/tags/billing": {URI: "scio://contexts/other/tags/billing", AllowedEntities: allEntities},
+		},
+		relations: map[string]*model.RelationType{
+			"scio://relations/uses": {
+				URI:                   "scio://relations/uses",
+				AllowedSourceEntities: allEntities,
+				AllowedTargetEntities: allEntities,
+			},
+			"scio://contexts/other/relations/owns": {
+				URI:                   "scio://contexts/other/relations/owns",
+				AllowedSourceEntities: allEntities,
+				AllowedTargetEntities: allEntities,
+			},
+		},
+		others: map[string]bool{otherURI: true},
+	})
+}
+
+func TestScope(t *testing.T) {
+	tests := []struct {
+		uri      string
+		expected string
+	}{
+		{"scio://contexts/shop", "shop"},
+		{"scio://contexts/shop/domains/pricing", "shop"},
+		{"scio://contexts/shop/domains/pricing/concepts/discount", "shop"},
+		{"scio://contexts/shop/tags/local", "shop"},
+		{"scio://tags/pricing", ""},
+		{"scio://relations/uses", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.uri, func(t *testing.T) {
+			u, err := uri.Parse(tc.uri)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, validation.Scope(u))
+		})
+	}
+}
+
+func TestResolve_PrefersContextLocalDefinition(t *testing.T) {
+	// given
+	c := &model.Concept{
+		URI:       conceptURI,
+		Tags:      []string{"pricing", "audit", "scio://tags/pricing"},
+		Relations: []model.RelationRef{{Type: "uses", Target: otherURI}},
+	}
+	// when
+	newScopedValidator().Resolve(c)
+	// then
+	assert.Equal(t, []string{"scio://contexts/shop/tags/pricing", "scio://tags/audit", "scio://tags/pricing"}, c.Tags)
+	assert.Equal(t, "scio://relations/uses", c.Relations[0].Type)
+}
+
+func TestResolve_GlobalEntityUsesGlobalDefinition(t *testing.T) {
+	// given
+	tag := &model.Tag{URI: "scio://tags/discount", Broader: []string{"pricing"}}
+	// when
+	newScopedValidator().Resolve(tag)
+	// then
+	assert.Equal(t, []string{"scio://tags/pricing"}, tag.Broader)
+}
+
+func TestResolve_DoesNotAliasInput(t *testing.T) {
+	// given
+	tags := []string{"pricing"}
+	c := &model.Concept{URI: conceptURI, Tags: tags}
+	// when
+	newScopedValidator().Resolve(c)
+	// then
+	assert.Equal(t, []string{"pricing"}, tags)
+}
+
+func TestViolations_ScopeViolation(t *testing.T) {
+	// given
+	c := &model.Concept{
+		URI:       conceptURI,
+		Tags:      []string{"scio://contexts/other/tags/billing", "scio://contexts/shop/tags/pricing"},
+		Relations: []model.RelationRef{{Type: "scio://contexts/other/relations/owns", Target: otherURI}},
+	}
+	// when
+	violations := newScopedValidator().Violations(c)
+	// then
+	require.Len(t, violations, 2)
+
+	for _, v := range violations {
+		assert.Equal(t, outputs.ErrScopeViolation, v.Code)
+		assert.Equal(t, "scio://contexts/other", v.AllowedScope)
+	}
+
+	assert.Equal(t, "scio://contexts/other/tags/billing", violations[0].Value)
+	assert.Equal(t, "relations[0].type", violations[1].Field)
+}
+
+func TestViolations_ContextMayUseItsOwnDefinitions(t *testing.T) {
+	// given
+	c := &model.Context{URI: "scio://contexts/other", Tags: []string{"scio://contexts/other/tags/billing"}}
+	// when
+	violations := newScopedValidator().Violations(c)
+	// then
+	assert.Empty(t, violations)
+}
+
+func TestViolations_TagLinkScope(t *testing.T) {
+	// given
+	local := &model.Tag{
+		URI:      "scio://contexts/shop/tags/discount",
+		Broader:  []string{"scio://tags/pricing", "scio://contexts/shop/tags/pricing"},
+		Narrower: []string{"scio://contexts/other/tags/billing"},
+	}
+	global := &model.Tag{URI: "scio://tags/discount", Broader: []string{"scio://contexts/shop/tags/pricing"}}
+	// when
+	localViolations := newScopedValidator().Violations(local)
+	globalViolations := newScopedValidator().Violations(global)
+	// then
+	require.Len(t, localViolations, 1)
+	assert.Equal(t, outputs.ErrScopeViolation, localViolations[0].Code)
+	assert.Equal(t, "narrower[0]", localViolations[0].Field)
+	assert.Equal(t, "scio://contexts/other", localViolations[0].AllowedScope)
+
+	require.Len(t, globalViolations, 1)
+	assert.Equal(t, outputs.ErrScopeViolation, globalViolations[0].Code)
+	assert.Equal(t, "scio://contexts/shop", globalViolations[0].AllowedScope)
+}
+
+func TestViolations_InverseOfScope(t *testing.T) {
+	// given
+	r := &model.RelationType{URI: "scio://contexts/shop/relations/owned-by", InverseOf: "scio://contexts/other/relations/owns"}
+	// when
+	violations := newScopedValidator().Violations(r)
+	// then
+	require.Len(t, violations, 1)
+	assert.Equal(t, outputs.ErrScopeViolation, violations[0].Code)
+	assert.Equal(t, "inverse_of", violations[0].Field)
+	assert.Equal(t, "scio://contexts/other", violations[0].AllowedScope)
+}
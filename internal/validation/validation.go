@@ -0,0 +1,298 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+)
+
+// Store gives the validator read access to the entities that references are
+// resolved against.
+type Store interface {
+	Exists(u *uri.URI) bool
+	Tag(u *uri.URI) (*model.Tag, error)
+	RelationType(u *uri.URI) (*model.RelationType, error)
+}
+
+// Violation is a single problem found while validating an entity.
+type Violation struct {
+	Code    string `json:"error_code"`
+	Field   string `json:"field"`
+	Value   string `json:"value"`
+	Message string `json:"message"`
+	// AllowedScope is set on scope violations to the only scope the
+	// referenced definition may be used from.
+	AllowedScope string `json:"allowed_scope,omitempty"`
+}
+
+type Validator struct {
+	store Store
+}
+
+func New(store Store) *Validator {
+	return &Validator{store: store}
+}
+
+// Validate checks every reference held by entity and returns all the
+// violations found as a single AppError, or nil if there are none.
+func (v *Validator) Validate(entity any) error {
+	violations := v.Violations(entity)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return Error(entityURI(entity), violations)
+}
+
+// Violations checks every reference held by entity, which must be a pointer
+// to one of the five model types, and returns the problems found.
+func (v *Validator) Violations(entity any) []Violation {
+	c := checker{scope: entityScope(entity)}
+
+	switch e := entity.(type) {
+	case *model.Context:
+		v.checkTags(&c, model.EntityTypeContext, e.Tags)
+		v.checkRelations(&c, model.EntityTypeContext, e.Relations)
+	case *model.Domain:
+		v.checkTags(&c, model.EntityTypeDomain, e.Tags)
+		v.checkRelations(&c, model.EntityTypeDomain, e.Relations)
+	case *model.Concept:
+		v.checkTags(&c, model.EntityTypeConcept, e.Tags)
+		v.checkRelations(&c, model.EntityTypeConcept, e.Relations)
+		checkSources(&c, e.Sources)
+	case *model.Tag:
+		v.checkTagLinks(&c, "broader", e.Broader)
+		v.checkTagLinks(&c, "narrower", e.Narrower)
+	case *model.RelationType:
+		v.checkInverseOf(&c, e.InverseOf)
+	}
+
+	return c.violations
+}
+
+// Error wraps violations found on the entity identified by rawURI into an
+// AppError. When all violations share a code, that code is used for the
+// error, otherwise it is reported as VALIDATION_FAILED.
+func Error(rawURI string, violations []Violation) *outputs.AppError {
+	code := violations[0].Code
+
+	for _, violation := range violations[1:] {
+		if violation.Code != code {
+			code = outputs.ErrValidationFailed
+			break
+		}
+	}
+
+	message := violations[0].Message
+	if len(violations) > 1 {
+		message = fmt.Sprintf("%s has %d invalid references", rawURI, len(violations))
+	}
+
+	return &outputs.AppError{
+		Message:   message,
+		ErrorCode: code,
+		Details: map[string]any{
+			"uri":        rawURI,
+			"violations": violations,
+		},
+		SuggestedAction: "Fix every reported violation and retry; create missing tags, relation types or targets first",
+		Recoverable:     true,
+	}
+}
+
+type checker struct {
+	scope      string
+	violations []Violation
+}
+
+func (c *checker) add(code, field, value, format string, args ...any) {
+	c.violations = append(c.violations, Violation{
+		Code:    code,
+		Field:   field,
+		Value:   value,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// parse resolves raw as a URI of the expected entity type, recording a
+// violation and returning nil when it is not.
+func (c *checker) parse(field, raw, entityType string) *uri.URI {
+	u, err := uri.Parse(raw)
+	if err != nil {
+		c.add(outputs.ErrInvalidURIFormat, field, raw, "%s", err)
+		return nil
+	}
+
+	if entityType != "" && u.Entity != entityType {
+		c.add(outputs.ErrTypeMismatch, field, raw, "%s is a %s, expected a %s", raw, u.Entity, entityType)
+		return nil
+	}
+
+	return u
+}
+
+// checkScope records a violation when the context-scoped definition u is
+// referenced from outside its context.
+func (c *checker) checkScope(field string, u *uri.URI) bool {
+	if u.Context == nil || *u.Context == c.scope {
+		return true
+	}
+
+	c.violations = append(c.violations, Violation{
+		Code:         outputs.ErrScopeViolation,
+		Field:        field,
+		Value:        u.Raw,
+		Message:      fmt.Sprintf("%s is local to %s and cannot be used from %s", u.Raw, scopeURI(*u.Context), scopeURI(c.scope)),
+		AllowedScope: scopeURI(*u.Context),
+	})
+
+	return false
+}
+
+func (v *Validator) checkTags(c *checker, entityType string, tags []string) {
+	for i, raw := range tags {
+		field := fmt.Sprintf("tags[%d]", i)
+
+		if slices.Contains(tags[:i], raw) {
+			c.add(outputs.ErrDuplicateTag, field, raw, "tag %s is listed more than once", raw)
+			continue
+		}
+
+		u := c.parse(field, raw, model.EntityTypeTag)
+		if u == nil || !c.checkScope(field, u) {
+			continue
+		}
+
+		if !v.store.Exists(u) {
+			c.add(outputs.ErrTagNotFound, field, raw, "tag %s does not exist", raw)
+			continue
+		}
+
+		tag, err := v.store.Tag(u)
+		if err != nil {
+			c.add(outputs.ErrTagNotFound, field, raw, "tag %s cannot be read: %s", raw, err)
+			continue
+		}
+
+		if !slices.Contains(tag.AllowedEntities, entityType) {
+			c.add(outputs.ErrTypeMismatch, field, raw, "tag %s cannot be applied to a %s, only to %v", raw, entityType, tag.AllowedEntities)
+		}
+	}
+}
+
+func (v *Validator) checkRelations(c *checker, entityType string, relations []model.RelationRef) {
+	for i, ref := range relations {
+		field := fmt.Sprintf("relations[%d]", i)
+
+		if slices.Contains(relations[:i], ref) {
+			c.add(outputs.ErrDuplicateRelation, field, ref.Type+" "+ref.Target, "relation %s to %s is listed more than once", ref.Type, ref.Target)
+			continue
+		}
+
+		relationType := v.resolveRelationType(c, field+".type", ref.Type)
+
+		if relationType != nil && !slices.Contains(relationType.AllowedSourceEntities, entityType) {
+			c.add(outputs.ErrTypeMismatch, field+".type", ref.Type, "relation %s cannot start at a %s, only at %v", ref.Type, entityType, relationType.AllowedSourceEntities)
+		}
+
+		target := c.parse(field+".target", ref.Target, "")
+		if target == nil {
+			continue
+		}
+
+		if !v.store.Exists(target) {
+			c.add(outputs.ErrTargetNotFound, field+".target", ref.Target, "relation target %s does not exist", ref.Target)
+			continue
+		}
+
+		if relationType != nil && !slices.Contains(relationType.AllowedTargetEntities, target.Entity) {
+			c.add(outputs.ErrTypeMismatch, field+".target", ref.Target, "relation %s cannot point at a %s, only at %v", ref.Type, target.Entity, relationType.AllowedTargetEntities)
+		}
+	}
+}
+
+func (v *Validator) resolveRelationType(c *checker, field, raw string) *model.RelationType {
+	u := c.parse(field, raw, model.EntityTypeRelation)
+	if u == nil || !c.checkScope(field, u) {
+		return nil
+	}
+
+	if !v.store.Exists(u) {
+		c.add(outputs.ErrRelationTypeNotFound, field, raw, "relation type %s does not exist", raw)
+		return nil
+	}
+
+	relationType, err := v.store.RelationType(u)
+	if err != nil {
+		c.add(outputs.ErrRelationTypeNotFound, field, raw, "relation type %s cannot be read: %s", raw, err)
+		return nil
+	}
+
+	return relationType
+}
+
+func checkSources(c *checker, sources []model.Source) {
+	for i, source := range sources {
+		field := fmt.Sprintf("sources[%d]", i)
+
+		if source.Type == "" || source.Href == "" {
+			c.add(outputs.ErrInvalidSourceFormat, field, source.Type+" "+source.Href, "sources need both a type and an href")
+			continue
+		}
+
+		if slices.Contains(sources[:i], source) {
+			c.add(outputs.ErrDuplicateSource, field, source.Href, "source %s %s is listed more than once", source.Type, source.Href)
+		}
+	}
+}
+
+func (v *Validator) checkTagLinks(c *checker, field string, links []string) {
+	for i, raw := range links {
+		linkField := fmt.Sprintf("%s[%d]", field, i)
+
+		if slices.Contains(links[:i], raw) {
+			c.add(outputs.ErrDuplicateTag, linkField, raw, "tag %s is listed more than once", raw)
+			continue
+		}
+
+		u := c.parse(linkField, raw, model.EntityTypeTag)
+		if u != nil && c.checkScope(linkField, u) && !v.store.Exists(u) {
+			c.add(outputs.ErrTagNotFound, linkField, raw, "tag %s does not exist", raw)
+		}
+	}
+}
+
+func (v *Validator) checkInverseOf(c *checker, inverseOf string) {
+	if inverseOf != "" {
+		v.resolveRelationType(c, "inverse_of", inverseOf)
+	}
+}
+
+func entityScope(entity any) string {
+	u, err := uri.Parse(entityURI(entity))
+	if err != nil {
+		return ""
+	}
+
+	return Scope(u)
+}
+
+func entityURI(entity any) string {
+	switch e := entity.(type) {
+	case *model.Context:
+		return e.URI
+	case *model.Domain:
+		return e.URI
+	case *model.Concept:
+		return e.URI
+	case *model.Tag:
+		return e.URI
+	case *model.RelationType:
+		return e.URI
+	default:
+		return ""
+	}
+}
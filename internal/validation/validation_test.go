@@ -0,0 +1,218 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jjmrocha/knowledge-mcp/internal/model"
+	"github.com/jjmrocha/knowledge-mcp/internal/outputs"
+	"github.com/jjmrocha/knowledge-mcp/internal/uri"
+	"github.com/jjmrocha/knowledge-mcp/internal/validation"
+)
+
+// fakeStore is an in-memory validation.Store keyed by raw URI.
+type fakeStore struct {
+	tags      map[string]*model.Tag
+	relations map[string]*model.RelationType
+	others    map[string]bool
+}
+
+func (f *fakeStore) Exists(u *uri.URI) bool {
+	return f.tags[u.Raw] != nil || f.relations[u.Raw] != nil || f.others[u.Raw]
+}
+
+func (f *fakeStore) Tag(u *uri.URI) (*model.Tag, error) {
+	if t, ok := f.tags[u.Raw]; ok {
+		return t, nil
+	}
+
+	return nil, errors.New("not found")
+}
+
+func (f *fakeStore) RelationType(u *uri.URI) (*model.RelationType, error) {
+	if r, ok := f.relations[u.Raw]; ok {
+		return r, nil
+	}
+
+	return nil, errors.New("not found")
+}
+
+const (
+	conceptURI = "scio://contexts/shop/domains/pricing/concepts/discount"
+	otherURI   = "scio://contexts/shop/domains/pricing/concepts/coupon"
+	domainURI  = "scio://contexts/shop/domains/pricing"
+)
+
+func newValidator() *validation.Validator {
+	return validation.New(&fakeStore{
+		tags: map[string]*model.Tag{
+			"scio://tags/pricing":     {URI: "scio://tags/pricing", AllowedEntities: []string{"context", "domain", "concept"}},
+			"scio://tags/domain-only": {URI: "scio://tags/domain-only", AllowedEntities: []string{"domain"}},
+		},
+		relations: map[string]*model.RelationType{
+			"scio://relations/uses": {
+				URI:                   "scio://relations/uses",
+				AllowedSourceEntities: []string{"concept"},
+				AllowedTargetEntities: []string{"concept"},
+			},
+		},
+		others: map[string]bool{
+			otherURI:  true,
+			domainURI: true,
+		},
+	})
+}
+
+func violationCodes(violations []validation.Violation) []string {
+	codes := make([]string, 0, len(violations))
+
+	for _, v := range violations {
+		codes = append(codes, v.Code)
+	}
+
+	return codes
+}
+
+func TestValidate_ValidConcept(t *testing.T) {
+	// given
+	c := &model.Concept{
+		URI:       conceptURI,
+		Tags:      []string{"scio://tags/pricing"},
+		Relations: []model.RelationRef{{Type: "scio://relations/uses", Target: otherURI}},
+		Sources:   []model.Source{{Type: "file", Href: "discount.go"}},
+	}
+	// when
+	err := newValidator().Validate(c)
+	// then
+	assert.NoError(t, err)
+}
+
+func TestViolations_Tags(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		code string
+	}{
+		{"unknown tag", []string{"scio://tags/unknown"}, outputs.ErrTagNotFound},
+		{"not allowed for entity", []string{"scio://tags/domain-only"}, outputs.ErrTypeMismatch},
+		{"not a tag URI", []string{"scio://relations/uses"}, outputs.ErrTypeMismatch},
+		{"malformed URI", []string{"pricing"}, outputs.ErrInvalidURIFormat},
+		{"duplicate", []string{"scio://tags/pricing", "scio://tags/pricing"}, outputs.ErrDuplicateTag},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			violations := newValidator().Violations(&model.Concept{URI: conceptURI, Tags: tc.tags})
+
+			assert.Equal(t, []string{tc.code}, violationCodes(violations))
+		})
+	}
+}
+
+func TestViolations_Relations(t *testing.T) {
+	tests := []struct {
+		name     string
+		relation model.RelationRef
+		code     string
+	}{
+		{"unknown type", model.RelationRef{Type: "scio://relations/unknown", Target: otherURI}, outputs.ErrRelationTypeNotFound},
+		{"unknown target", model.RelationRef{Type: "scio://relations/uses", Target: conceptURI + "-x"}, outputs.ErrTargetNotFound},
+		{"target not allowed", model.RelationRef{Type: "scio://relations/uses", Target: domainURI}, outputs.ErrTypeMismatch},
+		{"malformed target", model.RelationRef{Type: "scio://relations/uses", Target: "coupon"}, outputs.ErrInvalidURIFormat},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			violations := newValidator().Violations(&model.Concept{URI: conceptURI, Relations: []model.RelationRef{tc.relation}})
+
+			assert.Equal(t, []string{tc.code}, violationCodes(violations))
+		})
+	}
+}
+
+func TestViolations_SourceNotAllowed(t *testing.T) {
+	// given — uses only starts at concepts
+	d := &model.Domain{URI: domainURI, Relations: []model.RelationRef{{Type: "scio://relations/uses", Target: otherURI}}}
+	// when
+	violations := newValidator().Violations(d)
+	// then
+	require.Len(t, violations, 1)
+	assert.Equal(t, outputs.ErrTypeMismatch, violations[0].Code)
+	assert.Equal(t, "relations[0].type", violations[0].Field)
+}
+
+func TestViolations_DuplicateRelation(t *testing.T) {
+	// given
+	ref := model.RelationRef{Type: "scio://relations/uses", Target: otherURI}
+	// when
+	violations := newValidator().Violations(&model.Concept{URI: conceptURI, Relations: []model.RelationRef{ref, ref}})
+	// then
+	assert.Equal(t, []string{outputs.ErrDuplicateRelation}, violationCodes(violations))
+}
+
+func TestViolations_Sources(t *testing.T) {
+	// given
+	source := model.Source{Type: "file", Href: "discount.go"}
+	// when
+	violations := newValidator().Violations(&model.Concept{
+		URI:     conceptURI,
+		Sources: []model.Source{source, source, {Type: "url"}},
+	})
+	// then
+	assert.Equal(t, []string{outputs.ErrDuplicateSource, outputs.ErrInvalidSourceFormat}, violationCodes(violations))
+}
+
+func TestViolations_TagLinks(t *testing.T) {
+	// given
+	tag := &model.Tag{
+		URI:      "scio://tags/discount",
+		Broader:  []string{"scio://tags/pricing", "scio://tags/unknown"},
+		Narrower: []string{"scio://tags/pricing", "scio://tags/pricing"},
+	}
+	// when
+	violations := newValidator().Violations(tag)
+	// then
+	assert.Equal(t, []string{outputs.ErrTagNotFound, outputs.ErrDuplicateTag}, violationCodes(violations))
+	assert.Equal(t, "narrower[1]", violations[1].Field)
+}
+
+func TestViolations_InverseOf(t *testing.T) {
+	// given
+	r := &model.RelationType{URI: "scio://relations/used-by", InverseOf: "scio://relations/unknown"}
+	// when
+	violations := newValidator().Violations(r)
+	// then
+	assert.Equal(t, []string{outputs.ErrRelationTypeNotFound}, violationCodes(violations))
+}
+
+func TestValidate_CollectsEveryViolation(t *testing.T) {
+	// given
+	c := &model.Concept{
+		URI:       conceptURI,
+		Tags:      []string{"scio://tags/unknown", "scio://tags/domain-only"},
+		Relations: []model.RelationRef{{Type: "scio://relations/unknown", Target: otherURI}},
+	}
+	// when
+	err := newValidator().Validate(c)
+	// then
+	var appErr *outputs.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, outputs.ErrValidationFailed, appErr.ErrorCode)
+	assert.Equal(t, conceptURI, appErr.Details["uri"])
+	assert.Len(t, appErr.Details["violations"], 3)
+}
+
+func TestValidate_SingleCodeIsPromoted(t *testing.T) {
+	// given
+	c := &model.Concept{URI: conceptURI, Tags: []string{"scio://tags/a", "scio://tags/b"}}
+	// when
+	err := newValidator().Validate(c)
+	// then
+	var appErr *outputs.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, outputs.ErrTagNotFound, appErr.ErrorCode)
+	assert.Len(t, appErr.Details["violations"], 2)
+}